@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"strings"
+
+	"github.com/itchyny/gojq"
+	"github.com/spf13/cobra"
+)
+
+var apiMethod string
+var apiFields []string
+var apiInput string
+var apiJq string
+
+// apiCmd represents the api command
+var apiCmd = &cobra.Command{
+	Use:   "api <path>",
+	Short: "Send an authenticated request to the Kubero API",
+	Long: `Send a request to the REST API of the Kubero UI with the URL, token and TLS
+settings of the active profile, and print the response.
+
+Paths without a leading slash are relative to /api/cli. The method is GET, or
+POST if fields or an input file are given. Fields are sent as JSON object, or
+as query parameters for GET requests and when --input is used.
+
+JSON responses are pretty printed. --jq filters them with a jq expression;
+strings in its result are printed without quotes.`,
+	Example: `  kubero api pipelines
+  kubero api pipelines/example/apps --jq '.phases[].apps[].name'
+  kubero api config/podsize --jq '.[].name'
+  kubero api apps -X POST --input app.json
+  kubero api /api/pipelines/example -X DELETE`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		var jq *gojq.Code
+		if apiJq != "" {
+			query, err := gojq.Parse(apiJq)
+			if err != nil {
+				exitWithError(fmt.Errorf("invalid --jq expression: %w", err))
+			}
+			if jq, err = gojq.Compile(query); err != nil {
+				exitWithError(fmt.Errorf("invalid --jq expression: %w", err))
+			}
+		}
+
+		fields := map[string]string{}
+		for _, field := range apiFields {
+			key, value, found := strings.Cut(field, "=")
+			if !found || key == "" {
+				exitWithError(fmt.Errorf("invalid field '%s', expected key=value", field))
+			}
+			fields[key] = value
+		}
+
+		method := strings.ToUpper(apiMethod)
+		if method == "" {
+			method = http.MethodGet
+			if len(fields) > 0 || apiInput != "" {
+				method = http.MethodPost
+			}
+		}
+
+		path := args[0]
+		var body interface{}
+		switch {
+		case apiInput != "":
+			input, err := readAPIInput(apiInput)
+			if err != nil {
+				exitWithError(err)
+			}
+			body = input
+			path = addQuery(path, fields)
+		case method == http.MethodGet || method == http.MethodHead:
+			path = addQuery(path, fields)
+		case len(fields) > 0:
+			body = fields
+		}
+
+		resp, err := client.Raw(cmd.Context(), method, path, body)
+		if err != nil {
+			exitWithError(err)
+		}
+
+		if err := printAPIResponse(resp.Body(), jq); err != nil {
+			exitWithError(err)
+		}
+	},
+}
+
+func init() {
+	apiCmd.Flags().StringVarP(&apiMethod, "method", "X", "", "HTTP method of the request")
+	apiCmd.Flags().StringArrayVarP(&apiFields, "field", "f", []string{}, "Add a string field key=value to the request")
+	apiCmd.Flags().StringVar(&apiInput, "input", "", "File with the request body, - for stdin")
+	apiCmd.Flags().StringVarP(&apiJq, "jq", "q", "", "Filter the JSON response with a jq expression")
+	rootCmd.AddCommand(apiCmd)
+}
+
+func readAPIInput(fileName string) ([]byte, error) {
+	if fileName == "-" {
+		return io.ReadAll(os.Stdin)
+	}
+	return os.ReadFile(fileName)
+}
+
+// addQuery adds the fields as query parameters to the path.
+func addQuery(path string, fields map[string]string) string {
+	if len(fields) == 0 {
+		return path
+	}
+	path, rawQuery, _ := strings.Cut(path, "?")
+	query, err := url.ParseQuery(rawQuery)
+	if err != nil {
+		exitWithError(fmt.Errorf("invalid query in path: %w", err))
+	}
+	for key, value := range fields {
+		query.Set(key, value)
+	}
+	return path + "?" + query.Encode()
+}
+
+func printAPIResponse(body []byte, jq *gojq.Code) error {
+	if len(body) == 0 {
+		return nil
+	}
+
+	if !json.Valid(body) {
+		if jq != nil {
+			return fmt.Errorf("--jq needs a JSON response")
+		}
+		_, err := os.Stdout.Write(body)
+		return err
+	}
+
+	if jq == nil {
+		var pretty bytes.Buffer
+		if err := json.Indent(&pretty, body, "", "  "); err != nil {
+			return err
+		}
+		fmt.Println(pretty.String())
+		return nil
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err != nil {
+		return err
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+
+	results := jq.Run(data)
+	for {
+		result, ok := results.Next()
+		if !ok {
+			return nil
+		}
+		switch v := result.(type) {
+		case error:
+			return fmt.Errorf("--jq: %w", v)
+		case string:
+			fmt.Println(v)
+		default:
+			if err := encoder.Encode(v); err != nil {
+				return err
+			}
+		}
+	}
+}
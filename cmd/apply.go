@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/i582/cfmt/cmd/cfmt"
+	"github.com/spf13/cobra"
+)
+
+var manifestFiles []string
+var dryRun bool
+
+var applyCmd = &cobra.Command{
+	Use:   "apply -f <file|dir>",
+	Short: "Create or update pipelines and apps from pipeline.yaml and app.<phase>.yaml files",
+	Long: `Create or update pipelines and apps from KuberoPipeline and KuberoApp
+documents, as written by 'pipelines create', 'apps create' and the fetch
+commands.
+
+Objects missing on the server are created, existing ones are updated if the
+document differs from the server. A document describes the complete object.
+Directories are searched for *.yaml and *.yml files, files with other kinds of
+documents are skipped. Pipelines are applied before apps.`,
+	Example: `  kubero apply -f pipeline.yaml
+  kubero apply -f app.production.yaml
+  kubero apply -f . --dry-run`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		manifests, err := readManifests(manifestFiles)
+		if err != nil {
+			exitWithError(err)
+		}
+
+		suffix := ""
+		if dryRun {
+			suffix = " (dry run)"
+		}
+
+		var created, configured, unchanged int
+		for _, m := range manifests {
+			live, err := m.live(cmd.Context())
+			if err != nil {
+				exitApplyError(m, err)
+			}
+
+			if live == nil {
+				if !dryRun {
+					err = createManifest(cmd, m)
+				}
+				if err != nil {
+					exitApplyError(m, err)
+				}
+				created++
+				cfmt.Println("{{✓ " + m.String() + " created" + suffix + "}}::green")
+				continue
+			}
+
+			changes, err := specChanges(live, m.spec())
+			if err != nil {
+				exitApplyError(m, err)
+			}
+			if len(changes) == 0 {
+				unchanged++
+				fmt.Println("  " + m.String() + " unchanged")
+				continue
+			}
+
+			if !dryRun {
+				err = updateManifest(cmd, m)
+			}
+			if err != nil {
+				exitApplyError(m, err)
+			}
+			configured++
+			cfmt.Println("{{✓ " + m.String() + " configured" + suffix + "}}::green")
+			for _, change := range changes {
+				fmt.Println("    ~ " + change.String())
+			}
+		}
+
+		fmt.Printf("\n%d created, %d configured, %d unchanged%s\n", created, configured, unchanged, suffix)
+	},
+}
+
+func init() {
+	applyCmd.Flags().StringArrayVarP(&manifestFiles, "filename", "f", nil, "File or directory with the documents to apply (repeatable)")
+	applyCmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only show what would be created or updated")
+	applyCmd.MarkFlagRequired("filename")
+	rootCmd.AddCommand(applyCmd)
+}
+
+func createManifest(cmd *cobra.Command, m *manifest) error {
+	var err error
+	if m.pipeline != nil {
+		_, err = client.CreatePipeline(cmd.Context(), &m.pipeline.Spec)
+	} else {
+		_, err = client.CreateApp(cmd.Context(), &m.app.Spec)
+	}
+	return err
+}
+
+func updateManifest(cmd *cobra.Command, m *manifest) error {
+	var err error
+	if m.pipeline != nil {
+		_, err = client.UpdatePipeline(cmd.Context(), &m.pipeline.Spec)
+	} else {
+		_, err = client.UpdateApp(cmd.Context(), m.app.Spec.Pipeline, m.app.Spec.Phase, m.app.Spec.Name, &m.app.Spec)
+	}
+	return err
+}
+
+// exitApplyError names the document that failed before exiting. Documents
+// applied before stay applied.
+func exitApplyError(m *manifest, err error) {
+	fmt.Fprintf(os.Stderr, "Applying %s from %s failed\n", m.String(), m.file)
+	exitWithError(err)
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"kubero/pkg/kuberoapi"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/i582/cfmt/cmd/cfmt"
+	"github.com/spf13/cobra"
+)
+
+var buildRef string
+var followBuild bool
+
+var appsBuildCmd = &cobra.Command{
+	Use:   "build",
+	Short: "Build and deploy an app",
+	Long: `Build the image of an app from its branch, or from the branch, tag or commit
+given with --ref, and deploy it.
+
+With --follow the logs of the fetch, build and run steps are printed until the
+build is finished, and the command fails if the build fails.
+
+The pipeline, phase and app default to the ones in pipeline.yaml and
+app.<phase>.yaml of the current directory.`,
+	Example: `  kubero apps build -p shop -s stage -a web --follow
+  kubero apps build -s stage --ref v1.4.2 --follow`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := resolveApp(); err != nil {
+			exitWithError(err)
+		}
+		requireCapability(cmd.Context(), kuberoapi.CapabilityBuilds)
+
+		build, err := client.TriggerBuild(cmd.Context(), pipeline, stage, app, &kuberoapi.BuildRequest{Ref: buildRef})
+		if err != nil {
+			exitWithError(err)
+		}
+		cfmt.Println("{{✓ Build " + build.Name + " of app " + app + " started}}::green")
+		if !followBuild {
+			return
+		}
+
+		build, err = followBuildLogs(cmd, build)
+		if err != nil {
+			exitWithError(err)
+		}
+		duration := ""
+		if build.CompletionTime != nil {
+			duration = " in " + formatDuration(build.CompletionTime.Sub(build.StartTime))
+		}
+		if build.Status != kuberoapi.BuildSucceeded {
+			exitWithError(errors.New("build " + build.Name + " failed" + duration))
+		}
+		cfmt.Println("{{✓ Build " + build.Name + " succeeded" + duration + "}}::green")
+	},
+}
+
+func init() {
+	appsBuildCmd.Flags().StringVarP(&pipeline, "pipeline", "p", "", "Name of the pipeline")
+	appsBuildCmd.Flags().StringVarP(&stage, "stage", "s", "", "Name of the stage")
+	appsBuildCmd.Flags().StringVarP(&app, "app", "a", "", "Name of the app")
+	appsBuildCmd.Flags().StringVar(&buildRef, "ref", "", "Branch, tag or commit to build, default the branch of the app")
+	appsBuildCmd.Flags().BoolVarP(&followBuild, "follow", "f", false, "Print the build logs until the build is finished")
+	appsCmd.AddCommand(appsBuildCmd)
+}
+
+// followBuildLogs prints the logs of the build steps, each after a header,
+// until the build is finished and returns the finished build.
+func followBuildLogs(cmd *cobra.Command, build *kuberoapi.Build) (*kuberoapi.Build, error) {
+	started := map[string]bool{}
+	finished := map[string]bool{}
+	seen := map[string]bool{}
+
+	for {
+		var err error
+		build, err = client.GetBuild(cmd.Context(), pipeline, stage, app, build.Name)
+		if err != nil {
+			return nil, err
+		}
+		lines, err := client.GetBuildLogs(cmd.Context(), pipeline, stage, app, build.Name)
+		if err != nil {
+			return nil, err
+		}
+		sort.SliceStable(lines, func(i, j int) bool { return lines[i].Time < lines[j].Time })
+
+		for _, step := range build.Steps {
+			if step.StartTime.IsZero() {
+				// later steps can't have started either
+				break
+			}
+			if !started[step.Name] {
+				started[step.Name] = true
+				fmt.Println(colorize("==> "+step.Name, "cyan"))
+			}
+			for _, line := range lines {
+				if line.Container == step.Name && !seen[logLineKey(line)] {
+					seen[logLineKey(line)] = true
+					fmt.Println("    " + strings.TrimRight(line.Log, "\n"))
+				}
+			}
+			if step.CompletionTime != nil && !finished[step.Name] {
+				finished[step.Name] = true
+				duration := formatDuration(step.CompletionTime.Sub(step.StartTime))
+				if step.Status == kuberoapi.BuildFailed {
+					cfmt.Println("{{✗ " + step.Name + " failed (" + duration + ")}}::red")
+				} else {
+					cfmt.Println("{{✓ " + step.Name + " (" + duration + ")}}::green")
+				}
+			}
+		}
+
+		// servers not reporting steps only send the lines
+		if len(build.Steps) == 0 {
+			for _, line := range lines {
+				if !seen[logLineKey(line)] {
+					seen[logLineKey(line)] = true
+					printLogLine(line)
+				}
+			}
+		}
+
+		if build.Finished() {
+			return build, nil
+		}
+		time.Sleep(logsPollInterval)
+	}
+}
+
+func formatDuration(d time.Duration) string {
+	return d.Round(time.Second).String()
+}
@@ -1,8 +1,8 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
+	"kubero/pkg/kuberoapi"
 	"os"
 	"strconv"
 
@@ -25,15 +25,13 @@ If called without arguments, it will ask for all the required information`,
 		createApp := appsForm()
 		writeAppYaml(createApp)
 
-		client.SetBody(createApp.Spec)
-		app, appErr := client.Post("/api/cli/apps")
+		app, appErr := client.CreateApp(cmd.Context(), &createApp.Spec)
 
 		if appErr != nil {
-			fmt.Println(appErr)
+			exitWithError(appErr)
 		} else {
-			fmt.Println(app)
 			cfmt.Println("{{App created successfully}}::green")
-			json.Unmarshal(app.Body(), &createApp.Spec)
+			createApp.Spec = *app
 			writeAppYaml(createApp)
 		}
 
@@ -43,125 +41,11 @@ If called without arguments, it will ask for all the required information`,
 func init() {
 	appsCreateCmd.PersistentFlags().BoolVarP(&force, "force", "f", false, "Skip asking for confirmation")
 	appsCreateCmd.Flags().StringVarP(&stage, "stage", "s", "", "Name of the stage")
+	appsCreateCmd.Flags().StringVar(&pullSecret, "pull-secret", "", "Image pull secret for a private registry, for apps of docker pipelines")
 	appsCmd.AddCommand(appsCreateCmd)
 }
 
-type CreateApp struct {
-	APIVersion string `json:"apiVersion"`
-	Kind       string `json:"kind"`
-	Metadata   struct {
-	} `json:"metadata"`
-	Spec struct {
-		Addons   []interface{} `json:"addons"`
-		Affinity struct {
-		} `json:"affinity"`
-		Autodeploy  bool `json:"autodeploy"`
-		Autoscale   bool `json:"autoscale"`
-		Autoscaling struct {
-			Enabled bool `json:"enabled"`
-		} `json:"autoscaling"`
-		Branch           string        `json:"branch"`
-		Buildpack        string        `json:"buildpack"`
-		Cronjobs         []interface{} `json:"cronjobs"`
-		Domain           string        `json:"domain"`
-		EnvVars          []interface{} `json:"envvars"`
-		FullnameOverride string        `json:"fullnameOverride"`
-		Gitrepo          struct {
-			Admin         bool   `json:"admin"`
-			CloneURL      string `json:"clone_url"`
-			DefaultBranch string `json:"default_branch"`
-			Description   string `json:"description"`
-			Homepage      string `json:"homepage"`
-			ID            int    `json:"id"`
-			Language      string `json:"language"`
-			Name          string `json:"name"`
-			NodeID        string `json:"node_id"`
-			Owner         string `json:"owner"`
-			Private       bool   `json:"private"`
-			Push          bool   `json:"push"`
-			SSHURL        string `json:"ssh_url"`
-			Visibility    string `json:"visibility"`
-		} `json:"gitrepo"`
-		Image struct {
-			Fetch struct {
-				Repository string `json:"repository"`
-				Tag        string `json:"tag"`
-			} `json:"fetch"`
-			Build struct {
-				Command    string `json:"command"`
-				Repository string `json:"repository"`
-				Tag        string `json:"tag"`
-			} `json:"build"`
-			Run struct {
-				Command    string `json:"command"`
-				Repository string `json:"repository"`
-				Tag        string `json:"tag"`
-			} `json:"run"`
-			ContainerPort int    `json:"containerPort"`
-			PullPolicy    string `json:"pullPolicy"`
-			Repository    string `json:"repository"`
-			Tag           string `json:"tag"`
-		} `json:"image"`
-		ImagePullSecrets []interface{} `json:"imagePullSecrets"`
-		Ingress          struct {
-			Annotations struct {
-			} `json:"annotations"`
-			ClassName string `json:"className"`
-			Enabled   bool   `json:"enabled"`
-			Hosts     []struct {
-				Host  string `json:"host"`
-				Paths []struct {
-					Path     string `json:"path"`
-					PathType string `json:"pathType"`
-				} `json:"paths"`
-			} `json:"hosts"`
-			TLS []interface{} `json:"tls"`
-		} `json:"ingress"`
-		Name         string `json:"appname"`
-		NameOverride string `json:"nameOverride"`
-		NodeSelector struct {
-		} `json:"nodeSelector"`
-		Phase          string `json:"phase"`
-		Pipeline       string `json:"pipeline"`
-		PodAnnotations struct {
-		} `json:"podAnnotations"`
-		PodSecurityContext struct {
-		} `json:"podSecurityContext"`
-		Podsize      string `json:"podsize"`
-		ReplicaCount int    `json:"replicaCount"`
-		Service      struct {
-			Port int    `json:"port"`
-			Type string `json:"type"`
-		} `json:"service"`
-		ServiceAccount struct {
-			Annotations struct {
-			} `json:"annotations"`
-			Create bool   `json:"create"`
-			Name   string `json:"name"`
-		} `json:"serviceAccount"`
-		Tolerations []interface{} `json:"tolerations"`
-		Web         struct {
-			Autoscaling struct {
-				MaxReplicas                       int `json:"maxReplicas"`
-				MinReplicas                       int `json:"minReplicas"`
-				TargetCPUUtilizationPercentage    int `json:"targetCPUUtilizationPercentage"`
-				TargetMemoryUtilizationPercentage int `json:"targetMemoryUtilizationPercentage"`
-			} `json:"autoscaling"`
-			ReplicaCount int `json:"replicaCount"`
-		} `json:"web"`
-		Worker struct {
-			Autoscaling struct {
-				MaxReplicas                       int `json:"maxReplicas"`
-				MinReplicas                       int `json:"minReplicas"`
-				TargetCPUUtilizationPercentage    int `json:"targetCPUUtilizationPercentage"`
-				TargetMemoryUtilizationPercentage int `json:"targetMemoryUtilizationPercentage"`
-			} `json:"autoscaling"`
-			ReplicaCount int `json:"replicaCount"`
-		} `json:"worker"`
-	} `json:"spec"`
-}
-
-func writeAppYaml(app CreateApp) {
+func writeAppYaml(app kuberoapi.CreateApp) {
 	// write pipeline.yaml
 	yamlData, err := yaml.Marshal(&app)
 
@@ -177,9 +61,9 @@ func writeAppYaml(app CreateApp) {
 	}
 }
 
-func appsForm() CreateApp {
+func appsForm() kuberoapi.CreateApp {
 
-	var ca CreateApp
+	var ca kuberoapi.CreateApp
 
 	ca.APIVersion = "application.kubero.dev/v1alpha1"
 	ca.Kind = "KuberoApp"
@@ -195,29 +79,56 @@ func appsForm() CreateApp {
 
 	ca.Spec.Domain = promptLine("Domain", "", appconfig.GetString("spec.domain"))
 
-	gitURL := pipelineConfig.GetString("spec.git.repository.sshurl")
-	//ca.Spec.Gitrepo.SSHURL = promptLine("Git SSH URL", "["+getGitRemote()+"]", gitURL)
+	ca.Spec.Deploymentstrategy = pipelineConfig.GetString("spec.deploymentstrategy")
+	if ca.Spec.Deploymentstrategy == kuberoapi.StrategyDocker {
+		ca.Spec.Image.Repository = pipelineConfig.GetString("spec.dockerimage")
+		tagDefault := appconfig.GetString("spec.image.tag")
+		if tagDefault == "" {
+			tagDefault = "latest"
+		}
+		ca.Spec.Image.Tag = promptLine("Image Tag", ca.Spec.Image.Repository+":", tagDefault)
+
+		if pullSecret == "" {
+			secretDefault := pullSecretName(appconfig.Get("spec.imagepullsecrets"))
+			if secretDefault == "" {
+				secretDefault = pipelineConfig.GetString("spec.imagepullsecret")
+			}
+			pullSecret = promptLine("Image Pull Secret", "[empty for public images]", secretDefault)
+		}
+		if pullSecret != "" {
+			ca.Spec.ImagePullSecrets = []interface{}{map[string]interface{}{"name": pullSecret}}
+		}
+	} else {
+		gitURL := pipelineConfig.GetString("spec.git.repository.sshurl")
+		//ca.Spec.Gitrepo.SSHURL = promptLine("Git SSH URL", "["+getGitRemote()+"]", gitURL)
 
-	//ca.Spec.Gitrepo.SSHURL = pipelineConfig.GetString("spec.git.repository")
-	pipelineConfig.UnmarshalKey("spec.git.repository", &ca.Spec.Gitrepo)
-	ca.Spec.Branch = promptLine("Branch", gitURL+":", appconfig.GetString("spec.branch"))
+		//ca.Spec.Gitrepo.SSHURL = pipelineConfig.GetString("spec.git.repository")
+		pipelineConfig.UnmarshalKey("spec.git.repository", &ca.Spec.Gitrepo)
+		ca.Spec.Branch = promptLine("Branch", gitURL+":", appconfig.GetString("spec.branch"))
 
-	ca.Spec.Buildpack = pipelineConfig.GetString("spec.buildpack.name")
+		ca.Spec.Buildpack = pipelineConfig.GetString("spec.buildpack.name")
 
-	autodeployDefault := "n"
-	if !appconfig.GetBool("spec.autodeploy") {
-		autodeployDefault = "y"
-	}
-	autodeploy := promptLine("Autodeploy", "[y,n]", autodeployDefault)
-	if autodeploy == "Y" {
-		ca.Spec.Autodeploy = true
-	} else {
-		ca.Spec.Autodeploy = false
+		autodeployDefault := "n"
+		if !appconfig.GetBool("spec.autodeploy") {
+			autodeployDefault = "y"
+		}
+		autodeploy := promptLine("Autodeploy", "[y,n]", autodeployDefault)
+		if autodeploy == "Y" {
+			ca.Spec.Autodeploy = true
+		} else {
+			ca.Spec.Autodeploy = false
+		}
 	}
 
-	envCount, _ := strconv.Atoi(promptLine("Number of Env Vars", "", "0"))
+	// keep the env vars of app.<phase>.yaml, they are managed with 'apps env'
+	envVars, err := configEnvVars(appconfig)
+	if err != nil {
+		exitWithError(err)
+	}
+	ca.Spec.EnvVars = envVars
+	envCount, _ := strconv.Atoi(promptLine("Number of additional Env Vars", "", "0"))
 	for i := 0; i < envCount; i++ {
-		ca.Spec.EnvVars = append(ca.Spec.EnvVars, promptLine("Env Var", "", ""))
+		ca.Spec.EnvVars = append(ca.Spec.EnvVars, kuberoapi.ParseEnvVar(promptLine("Env Var", "NAME=value", "")))
 	}
 
 	ca.Spec.Image.ContainerPort, _ = strconv.Atoi(promptLine("Container Port", "8080", appconfig.GetString("spec.image.containerport")))
@@ -242,6 +153,21 @@ func getPipelinePhases() []string {
 	return phases
 }
 
+// configEnvVars returns spec.envvars of an app config. It is decoded as YAML,
+// since viper's decoder would not accept the "NAME=value" entries of older
+// versions of the CLI.
+func configEnvVars(appConfig *viper.Viper) ([]kuberoapi.EnvVar, error) {
+	data, err := yaml.Marshal(appConfig.Get("spec.envvars"))
+	if err != nil {
+		return nil, err
+	}
+	var envVars []kuberoapi.EnvVar
+	if err := yaml.Unmarshal(data, &envVars); err != nil {
+		return nil, fmt.Errorf("reading spec.envvars of %s: %w", appConfig.ConfigFileUsed(), err)
+	}
+	return envVars, nil
+}
+
 func loadAppConfig(phase string) *viper.Viper {
 
 	appConfig := viper.New()
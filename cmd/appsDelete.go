@@ -1,8 +1,7 @@
 package cmd
 
 import (
-	"fmt"
-
+	"github.com/i582/cfmt/cmd/cfmt"
 	"github.com/spf13/cobra"
 )
 
@@ -12,13 +11,14 @@ var appsDeleteCmd = &cobra.Command{
 	Short: "Delete a existing app in a pipeline",
 	Long:  `Delete a existing app in a pipeline`,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("delete called")
-
-		appInstance, _ := client.Delete("/api/cli/pipelines/" + pipeline + "/" + stage + "/" + app)
 
-		fmt.Println(appInstance)
+		appErr := client.DeleteApp(cmd.Context(), pipeline, stage, app)
 
-		fmt.Println(appInstance.StatusCode())
+		if appErr != nil {
+			exitWithError(appErr)
+		} else {
+			cfmt.Println("{{App deleted successfully}}::green")
+		}
 	},
 }
 
@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"fmt"
+	"kubero/pkg/kuberoapi"
+	"strings"
+
+	"github.com/i582/cfmt/cmd/cfmt"
+	"github.com/spf13/cobra"
+)
+
+var deployImage string
+var pullSecret string
+
+var appsDeployCmd = &cobra.Command{
+	Use:   "deploy",
+	Short: "Deploy a prebuilt image to an app",
+	Long: `Deploy another tag of the container image of an app, without building it.
+Only the tag is changed, the repository has to be the one the app runs. Use
+--pull-secret to pull the image from a private registry with the given
+image pull secret of the namespace, in addition to the ones the app has.
+
+The app has to use the docker deployment strategy, see
+'kubero pipelines create --strategy docker'.
+
+The pipeline, phase and app default to the ones in pipeline.yaml and
+app.<phase>.yaml of the current directory.`,
+	Example: `  kubero apps deploy -p shop -s production -a web --image registry/shop:1.4.2
+  kubero apps deploy -s stage --image ghcr.io/acme/shop:1.5.0 --pull-secret ghcr --wait`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := resolveApp(); err != nil {
+			exitWithError(err)
+		}
+		if waitReady {
+			requireCapability(cmd.Context(), kuberoapi.CapabilityPods)
+		}
+		current, err := client.GetApp(cmd.Context(), pipeline, stage, app)
+		if err != nil {
+			exitWithError(err)
+		}
+		if current.Spec.Deploymentstrategy == kuberoapi.StrategyGit {
+			exitWithError(fmt.Errorf("app %s is built from git, the next build would replace the image, use 'kubero apps build'", app))
+		}
+
+		repository, tag := splitImage(deployImage)
+		spec := current.Spec
+		if spec.Image.Repository != "" && spec.Image.Repository != repository {
+			exitWithError(fmt.Errorf("app %s runs images of %s, not %s; change the repository with 'kubero apply'", app, spec.Image.Repository, repository))
+		}
+		spec.Image.Repository = repository
+		spec.Image.Tag = tag
+		if pullSecret != "" {
+			spec.ImagePullSecrets = addPullSecret(spec.ImagePullSecrets, pullSecret)
+		}
+
+		changes, err := specChanges(&current.Spec, &spec)
+		if err != nil {
+			exitWithError(err)
+		}
+		if len(changes) == 0 {
+			fmt.Println("  app " + app + " already runs " + deployImage)
+			return
+		}
+		for _, change := range changes {
+			fmt.Println("    ~ " + change.String())
+		}
+		if _, err := client.UpdateApp(cmd.Context(), pipeline, stage, app, &spec); err != nil {
+			exitWithError(err)
+		}
+		cfmt.Println("{{✓ App " + app + " deploys " + specImage(&spec) + "}}::green")
+
+		if waitReady {
+			image := specImage(&spec)
+			waitForPods(cmd.Context(), "Waiting for the pods to run "+image, func(pods []kuberoapi.Pod) (bool, string) {
+				updated := 0
+				for _, pod := range pods {
+					if pod.Ready() && podRunsImage(pod, image) {
+						updated++
+					}
+				}
+				return updated > 0 && updated == len(pods), fmt.Sprintf("%d/%d pods run %s", updated, len(pods), tag)
+			})
+		}
+	},
+}
+
+func init() {
+	appsDeployCmd.Flags().StringVarP(&pipeline, "pipeline", "p", "", "Name of the pipeline")
+	appsDeployCmd.Flags().StringVarP(&stage, "stage", "s", "", "Name of the stage")
+	appsDeployCmd.Flags().StringVarP(&app, "app", "a", "", "Name of the app")
+	appsDeployCmd.Flags().StringVar(&deployImage, "image", "", "* Image to deploy, e.g. registry/foo:1.4.2")
+	appsDeployCmd.Flags().StringVar(&pullSecret, "pull-secret", "", "Name of the image pull secret for a private registry")
+	appsDeployCmd.MarkFlagRequired("image")
+	addWaitFlags(appsDeployCmd)
+	appsCmd.AddCommand(appsDeployCmd)
+}
+
+// podRunsImage reports if a container of the pod runs the image. Kubernetes
+// reports images of Docker Hub with the registry, e.g. docker.io/library/.
+func podRunsImage(pod kuberoapi.Pod, image string) bool {
+	for _, c := range pod.Containers {
+		if c.Image == image || strings.HasSuffix(c.Image, "/"+image) {
+			return true
+		}
+	}
+	return false
+}
+
+// pullSecretName returns the name of the first image pull secret of an app
+// spec or config.
+func pullSecretName(secrets interface{}) string {
+	list, _ := secrets.([]interface{})
+	for _, secret := range list {
+		if m, ok := secret.(map[string]interface{}); ok {
+			if name, ok := m["name"].(string); ok {
+				return name
+			}
+		}
+	}
+	return ""
+}
+
+// addPullSecret returns a copy of the image pull secrets of an app spec with
+// the named one appended, unless it is already in the list.
+func addPullSecret(secrets []interface{}, name string) []interface{} {
+	for _, secret := range secrets {
+		if pullSecretName([]interface{}{secret}) == name {
+			return secrets
+		}
+	}
+	return append(append([]interface{}{}, secrets...), map[string]interface{}{"name": name})
+}
@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestAddPullSecret(t *testing.T) {
+	secret := func(name string) interface{} {
+		return map[string]interface{}{"name": name}
+	}
+	tests := []struct {
+		name    string
+		secrets []interface{}
+		add     string
+		want    []interface{}
+	}{
+		{"no secrets", nil, "ghcr", []interface{}{secret("ghcr")}},
+		{"other secrets are kept", []interface{}{secret("dockerhub")}, "ghcr", []interface{}{secret("dockerhub"), secret("ghcr")}},
+		{"existing secret", []interface{}{secret("ghcr"), secret("dockerhub")}, "dockerhub", []interface{}{secret("ghcr"), secret("dockerhub")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := addPullSecret(tt.secrets, tt.add)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
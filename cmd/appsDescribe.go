@@ -0,0 +1,281 @@
+package cmd
+
+import (
+	"fmt"
+	"kubero/pkg/kuberoapi"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/i582/cfmt/cmd/cfmt"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+// number of events shown by describe
+const describeEvents = 10
+
+// appDescription is the app with its runtime state. The runtime parts are
+// left empty if the server could not tell, Warnings says why.
+type appDescription struct {
+	Spec      *kuberoapi.AppSpec `json:"spec"`
+	Pods      []kuberoapi.Pod    `json:"pods"`
+	LastBuild *kuberoapi.Build   `json:"lastBuild,omitempty"`
+	Events    []kuberoapi.Event  `json:"events"`
+	Warnings  []string           `json:"warnings,omitempty"`
+}
+
+var appsDescribeCmd = &cobra.Command{
+	Use:   "describe",
+	Short: "Show the configuration and the state of an app",
+	Long: `Show the configuration of an app together with its pods, the running image,
+the last build and the recent Kubernetes events.
+
+The pipeline, phase and app default to the ones in pipeline.yaml and
+app.<phase>.yaml of the current directory.`,
+	Example: `  kubero apps describe -p shop -s production -a web
+  kubero apps describe -s production -o yaml`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := resolveApp(); err != nil {
+			exitWithError(err)
+		}
+		ctx := cmd.Context()
+
+		a, err := client.GetApp(ctx, pipeline, stage, app)
+		if err != nil {
+			exitWithError(err)
+		}
+		d := &appDescription{Spec: &a.Spec, Pods: []kuberoapi.Pod{}, Events: []kuberoapi.Event{}}
+
+		// the runtime state is best effort, the configuration is shown anyway
+		unsupported := "not supported by this Kubero server"
+		if !serverSupports(ctx, kuberoapi.CapabilityPods) {
+			d.Warnings = append(d.Warnings, "pods: "+unsupported)
+		} else if pods, err := client.ListPods(ctx, pipeline, stage, app); err != nil {
+			d.Warnings = append(d.Warnings, "pods: "+errorDetail(err))
+		} else {
+			d.Pods = pods
+		}
+		if !serverSupports(ctx, kuberoapi.CapabilityBuilds) {
+			d.Warnings = append(d.Warnings, "builds: "+unsupported)
+		} else if builds, err := client.ListBuilds(ctx, pipeline, stage, app); err != nil {
+			d.Warnings = append(d.Warnings, "builds: "+errorDetail(err))
+		} else if len(builds) > 0 {
+			sort.SliceStable(builds, func(i, j int) bool { return builds[i].StartTime.After(builds[j].StartTime) })
+			d.LastBuild = &builds[0]
+		}
+		if !serverSupports(ctx, kuberoapi.CapabilityEvents) {
+			d.Warnings = append(d.Warnings, "events: "+unsupported)
+		} else if events, err := client.ListEvents(ctx, kuberoapi.Namespace(pipeline, stage)); err != nil {
+			d.Warnings = append(d.Warnings, "events: "+errorDetail(err))
+		} else {
+			d.Events = appEvents(events, app)
+		}
+
+		printObject(d, func() { printAppDescription(d) })
+	},
+}
+
+func init() {
+	appsDescribeCmd.Flags().StringVarP(&pipeline, "pipeline", "p", "", "Name of the pipeline")
+	appsDescribeCmd.Flags().StringVarP(&stage, "stage", "s", "", "Name of the stage")
+	appsDescribeCmd.Flags().StringVarP(&app, "app", "a", "", "Name of the app")
+	appsCmd.AddCommand(appsDescribeCmd)
+}
+
+// appEvents returns the most recent events of the objects of an app.
+func appEvents(events []kuberoapi.Event, app string) []kuberoapi.Event {
+	filtered := []kuberoapi.Event{}
+	for _, e := range events {
+		// the objects Kubero creates for an app are named <app>-kuberoapp-...,
+		// like web-kuberoapp-web-7d9f8c-x2x4z. A plain prefix match would
+		// also find the objects of the app web-api.
+		if name := e.InvolvedObject.Name; name == app || strings.HasPrefix(name, app+"-kuberoapp-") {
+			filtered = append(filtered, e)
+		}
+	}
+	sort.SliceStable(filtered, func(i, j int) bool { return filtered[i].LastTimestamp.After(filtered[j].LastTimestamp) })
+	if len(filtered) > describeEvents {
+		filtered = filtered[:describeEvents]
+	}
+	return filtered
+}
+
+func printAppDescription(d *appDescription) {
+	s := d.Spec
+	field := func(name string, value string) {
+		if value == "" {
+			value = "-"
+		}
+		fmt.Printf("%-13s %s\n", name+":", value)
+	}
+
+	field("Name", s.Name)
+	field("Pipeline", s.Pipeline)
+	field("Phase", s.Phase)
+	field("Domain", s.Domain)
+	field("Image", runningImage(d))
+	field("Buildpack", s.Buildpack)
+	branch := s.Branch
+	if s.Autodeploy {
+		branch += " (autodeploy)"
+	}
+	field("Branch", branch)
+	field("Podsize", s.Podsize)
+	field("Replicas", fmt.Sprintf("web %d, worker %d", s.Web.ReplicaCount, s.Worker.ReplicaCount))
+	autoscaling := "off"
+	if s.Autoscale {
+		autoscaling = fmt.Sprintf("web %d-%d pods at %d%% CPU", s.Web.Autoscaling.MinReplicas, s.Web.Autoscaling.MaxReplicas, s.Web.Autoscaling.TargetCPUUtilizationPercentage)
+	}
+	field("Autoscaling", autoscaling)
+	var hosts []string
+	for _, host := range s.Ingress.Hosts {
+		for _, path := range host.Paths {
+			hosts = append(hosts, host.Host+path.Path)
+		}
+		if len(host.Paths) == 0 {
+			hosts = append(hosts, host.Host)
+		}
+	}
+	field("Ingress", strings.Join(hosts, ", "))
+	field("Addons", describeItems(s.Addons, "kind", "name"))
+	field("Cronjobs", describeItems(s.Cronjobs, "name", "schedule"))
+	field("Env vars", strconv.Itoa(len(s.EnvVars)))
+
+	if b := d.LastBuild; b != nil {
+		build := b.Status + ", " + b.Name
+		if b.Commit != "" {
+			build += ", commit " + shortCommit(b.Commit)
+		}
+		build += ", started " + age(b.StartTime) + " ago"
+		field("Last build", build)
+	} else {
+		field("Last build", "")
+	}
+
+	cfmt.Println("\n{{Pods}}::bold")
+	if len(d.Pods) == 0 {
+		fmt.Println("  none")
+	} else {
+		table := describeTable([]string{"Name", "Status", "Ready", "Restarts", "Age", "Image"})
+		for _, pod := range d.Pods {
+			ready, restarts := 0, 0
+			var images []string
+			for _, c := range pod.Containers {
+				if c.Ready {
+					ready++
+				}
+				restarts += c.RestartCount
+				images = append(images, c.Image)
+			}
+			table.Append([]string{
+				pod.Name,
+				pod.Phase,
+				fmt.Sprintf("%d/%d", ready, len(pod.Containers)),
+				strconv.Itoa(restarts),
+				age(pod.StartTime),
+				strings.Join(images, ", "),
+			})
+		}
+		table.Render()
+	}
+
+	cfmt.Println("\n{{Events}}::bold")
+	if len(d.Events) == 0 {
+		fmt.Println("  none")
+	} else {
+		table := describeTable([]string{"Age", "Type", "Reason", "Object", "Message"})
+		for _, e := range d.Events {
+			table.Append([]string{
+				age(e.LastTimestamp),
+				e.Type,
+				e.Reason,
+				strings.ToLower(e.InvolvedObject.Kind) + "/" + e.InvolvedObject.Name,
+				e.Message,
+			})
+		}
+		table.Render()
+	}
+
+	if len(d.Warnings) > 0 {
+		cfmt.Fprintln(os.Stderr, "\n{{⚠ Runtime state incomplete}}::yellow")
+		for _, warning := range d.Warnings {
+			fmt.Fprintln(os.Stderr, "  "+warning)
+		}
+	}
+}
+
+func describeTable(headers []string) *tablewriter.Table {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader(headers)
+	table.SetBorder(false)
+	table.SetAutoWrapText(false)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	return table
+}
+
+// runningImage returns the image of the web container, or the configured one
+// if no web pod runs. Other containers run e.g. the buildpack or the worker.
+func runningImage(d *appDescription) string {
+	for _, pod := range d.Pods {
+		for _, c := range pod.Containers {
+			if c.Image != "" && (c.Name == kuberoapi.ContainerWeb || strings.HasSuffix(c.Name, "-"+kuberoapi.ContainerWeb)) {
+				return c.Image
+			}
+		}
+	}
+	if d.Spec.Image.Repository == "" {
+		return ""
+	}
+	return d.Spec.Image.Repository + ":" + d.Spec.Image.Tag
+}
+
+// describeItems lists addons or cronjobs by the given fields.
+func describeItems(items []interface{}, fields ...string) string {
+	var names []string
+	for _, item := range items {
+		m, ok := item.(map[string]interface{})
+		if !ok {
+			names = append(names, fmt.Sprint(item))
+			continue
+		}
+		var parts []string
+		for _, f := range fields {
+			if v, ok := m[f]; ok && v != "" {
+				parts = append(parts, fmt.Sprint(v))
+			}
+		}
+		names = append(names, strings.Join(parts, " "))
+	}
+	return strings.Join(names, ", ")
+}
+
+func shortCommit(commit string) string {
+	if len(commit) > 7 {
+		return commit[:7]
+	}
+	return commit
+}
+
+// age formats the time since t like kubectl, e.g. 45s, 12m, 3h or 5d.
+func age(t time.Time) string {
+	if t.IsZero() {
+		return "-"
+	}
+	d := time.Since(t)
+	switch {
+	case d < 0:
+		// the clocks of the server and the client differ
+		return "0s"
+	case d < time.Minute:
+		return strconv.Itoa(int(d.Seconds())) + "s"
+	case d < time.Hour:
+		return strconv.Itoa(int(d.Minutes())) + "m"
+	case d < 48*time.Hour:
+		return strconv.Itoa(int(d.Hours())) + "h"
+	}
+	return strconv.Itoa(int(d.Hours()/24)) + "d"
+}
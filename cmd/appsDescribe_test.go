@@ -0,0 +1,46 @@
+package cmd
+
+import (
+	"kubero/pkg/kuberoapi"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAppEvents(t *testing.T) {
+	now := time.Now()
+	event := func(name string, age time.Duration) kuberoapi.Event {
+		var e kuberoapi.Event
+		e.InvolvedObject.Name = name
+		e.LastTimestamp = now.Add(-age)
+		return e
+	}
+	events := []kuberoapi.Event{
+		event("web", 5*time.Minute),
+		event("web-kuberoapp-web-7d9f8c-x2x4z", time.Minute),
+		event("web-kuberoapp-worker", 3*time.Minute),
+		event("web-api", time.Minute),
+		event("web-api-kuberoapp-web-5c6d7e-abcde", time.Minute),
+		event("webshop-kuberoapp-web-1a2b3c-fghij", time.Minute),
+		event("web-7d9f8c-x2x4z", time.Minute),
+	}
+
+	tests := []struct {
+		app  string
+		want []string
+	}{
+		{"web", []string{"web-kuberoapp-web-7d9f8c-x2x4z", "web-kuberoapp-worker", "web"}},
+		{"web-api", []string{"web-api", "web-api-kuberoapp-web-5c6d7e-abcde"}},
+		{"shop", []string{}},
+	}
+
+	for _, tt := range tests {
+		names := []string{}
+		for _, e := range appEvents(events, tt.app) {
+			names = append(names, e.InvolvedObject.Name)
+		}
+		if !reflect.DeepEqual(names, tt.want) {
+			t.Errorf("events of %s = %v, want %v", tt.app, names, tt.want)
+		}
+	}
+}
@@ -0,0 +1,351 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"kubero/pkg/kuberoapi"
+	"os"
+	"regexp"
+	"strings"
+
+	"github.com/i582/cfmt/cmd/cfmt"
+	"github.com/spf13/cobra"
+)
+
+var envFromFiles []string
+var showSecrets bool
+
+var envNamePattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// names of env vars whose values are masked, in addition to sensitiveName
+var secretEnvName = regexp.MustCompile(`(?i)(key|dsn|salt|pass|cert)`)
+
+var appsEnvCmd = &cobra.Command{
+	Use:   "env",
+	Short: "Manage the environment variables of an app",
+	Long: `Manage the environment variables of an app. The changes are made to the app on
+the server, the rest of its configuration is kept.
+
+The pipeline, phase and app default to the ones in pipeline.yaml and
+app.<phase>.yaml of the current directory.`,
+}
+
+var appsEnvListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the environment variables of an app",
+	Long: `List the environment variables of an app. Values of variables that look like
+secrets, e.g. API_KEY or DB_PASSWORD, are masked unless --show-secrets is
+given.`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		current := fetchAppForEnv(cmd)
+
+		vars := make([]kuberoapi.EnvVar, len(current.Spec.EnvVars))
+		items := make([]interface{}, len(vars))
+		for i, v := range current.Spec.EnvVars {
+			vars[i] = kuberoapi.EnvVar{Name: v.Name, Value: maskEnvValue(v.Name, v.Value)}
+			items[i] = vars[i]
+		}
+
+		envVar := func(item interface{}) kuberoapi.EnvVar { return item.(kuberoapi.EnvVar) }
+		printList(outputList{
+			data:  vars,
+			items: items,
+			columns: []outputColumn{
+				{header: "Name", value: func(item interface{}) string { return envVar(item).Name }},
+				{header: "Value", value: func(item interface{}) string {
+					return strings.ReplaceAll(envVar(item).Value, "\n", `\n`)
+				}},
+			},
+			name: func(item interface{}) string { return envVar(item).Name },
+		})
+	},
+}
+
+var appsEnvSetCmd = &cobra.Command{
+	Use:   "set NAME=value...",
+	Short: "Add or change environment variables of an app",
+	Example: `  kubero apps env set -s production LOG_LEVEL=debug WORKERS=4
+  kubero apps env set -s production --from-file TLS_CERT=./cert.pem`,
+	Run: func(cmd *cobra.Command, args []string) {
+		if len(args) == 0 && len(envFromFiles) == 0 {
+			exitWithError(fmt.Errorf("nothing to set, give NAME=value or --from-file NAME=path"))
+		}
+
+		var vars []kuberoapi.EnvVar
+		for _, arg := range args {
+			if !strings.Contains(arg, "=") {
+				exitWithError(fmt.Errorf("invalid %q, expected NAME=value", arg))
+			}
+			vars = append(vars, kuberoapi.ParseEnvVar(arg))
+		}
+		for _, arg := range envFromFiles {
+			name, file, found := strings.Cut(arg, "=")
+			if !found {
+				exitWithError(fmt.Errorf("invalid --from-file %q, expected NAME=path", arg))
+			}
+			data, err := os.ReadFile(file)
+			if err != nil {
+				exitWithError(err)
+			}
+			vars = append(vars, kuberoapi.EnvVar{Name: name, Value: string(data)})
+		}
+
+		current := fetchAppForEnv(cmd)
+		updateEnv(cmd, current, mergeEnv(current.Spec.EnvVars, vars))
+	},
+}
+
+var appsEnvUnsetCmd = &cobra.Command{
+	Use:   "unset NAME...",
+	Short: "Remove environment variables from an app",
+	Args:  cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		remove := map[string]bool{}
+		for _, name := range args {
+			remove[name] = true
+		}
+
+		current := fetchAppForEnv(cmd)
+		var vars []kuberoapi.EnvVar
+		for _, v := range current.Spec.EnvVars {
+			if !remove[v.Name] {
+				vars = append(vars, v)
+			}
+		}
+		updateEnv(cmd, current, vars)
+	},
+}
+
+var appsEnvImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Add or change the environment variables of an app from a .env file",
+	Long: `Add or change the environment variables of an app from a .env file with one
+NAME=value per line. Values can be quoted with " or ' to span several lines.
+Variables not in the file are kept.`,
+	Example: `  kubero apps env import -s production .env.production`,
+	Args:    cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		vars, err := readDotenv(args[0])
+		if err != nil {
+			exitWithError(err)
+		}
+
+		current := fetchAppForEnv(cmd)
+		updateEnv(cmd, current, mergeEnv(current.Spec.EnvVars, vars))
+	},
+}
+
+var appsEnvExportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Print the environment variables of an app as .env file",
+	Long: `Print the environment variables of an app in the .env format read by
+'apps env import'. Secrets are not masked.`,
+	Example: `  kubero apps env export -s production > .env.production`,
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		current := fetchAppForEnv(cmd)
+		for _, v := range current.Spec.EnvVars {
+			fmt.Println(v.Name + "=" + quoteDotenv(v.Value))
+		}
+	},
+}
+
+func init() {
+	appsEnvCmd.PersistentFlags().StringVarP(&pipeline, "pipeline", "p", "", "Name of the pipeline")
+	appsEnvCmd.PersistentFlags().StringVarP(&stage, "stage", "s", "", "Name of the stage")
+	appsEnvCmd.PersistentFlags().StringVarP(&app, "app", "a", "", "Name of the app")
+	appsEnvListCmd.Flags().BoolVar(&showSecrets, "show-secrets", false, "Show the values of secrets")
+	appsEnvSetCmd.Flags().StringArrayVar(&envFromFiles, "from-file", nil, "Read the value from a file, as NAME=path (repeatable)")
+
+	appsEnvCmd.AddCommand(appsEnvListCmd)
+	appsEnvCmd.AddCommand(appsEnvSetCmd)
+	appsEnvCmd.AddCommand(appsEnvUnsetCmd)
+	appsEnvCmd.AddCommand(appsEnvImportCmd)
+	appsEnvCmd.AddCommand(appsEnvExportCmd)
+	appsCmd.AddCommand(appsEnvCmd)
+}
+
+func fetchAppForEnv(cmd *cobra.Command) *kuberoapi.CreateApp {
+	if err := resolveApp(); err != nil {
+		exitWithError(err)
+	}
+	current, err := client.GetApp(cmd.Context(), pipeline, stage, app)
+	if err != nil {
+		exitWithError(err)
+	}
+	return current
+}
+
+// mergeEnv changes the values of existing variables in place and appends
+// new ones.
+func mergeEnv(current []kuberoapi.EnvVar, changed []kuberoapi.EnvVar) []kuberoapi.EnvVar {
+	merged := append([]kuberoapi.EnvVar{}, current...)
+	for _, c := range changed {
+		if !envNamePattern.MatchString(c.Name) {
+			exitWithError(fmt.Errorf("invalid name %q, use letters, digits and _", c.Name))
+		}
+		found := false
+		for i := range merged {
+			if merged[i].Name == c.Name {
+				merged[i].Value = c.Value
+				found = true
+			}
+		}
+		if !found {
+			merged = append(merged, c)
+		}
+	}
+	return merged
+}
+
+// updateEnv saves vars as the env vars of the app and prints the names of
+// the changed ones. Values are not printed, they may be secrets.
+func updateEnv(cmd *cobra.Command, current *kuberoapi.CreateApp, vars []kuberoapi.EnvVar) {
+	before := map[string]string{}
+	for _, v := range current.Spec.EnvVars {
+		before[v.Name] = v.Value
+	}
+	after := map[string]bool{}
+
+	var changes []string
+	for _, v := range vars {
+		after[v.Name] = true
+		value, existed := before[v.Name]
+		if !existed {
+			changes = append(changes, "+ "+v.Name)
+		} else if value != v.Value {
+			changes = append(changes, "~ "+v.Name)
+		}
+	}
+	for _, v := range current.Spec.EnvVars {
+		if !after[v.Name] {
+			changes = append(changes, "- "+v.Name)
+		}
+	}
+
+	if len(changes) == 0 {
+		fmt.Println("  env vars of app " + app + " unchanged")
+		return
+	}
+
+	spec := current.Spec
+	spec.EnvVars = append([]kuberoapi.EnvVar{}, vars...)
+	if _, err := client.UpdateApp(cmd.Context(), pipeline, stage, app, &spec); err != nil {
+		exitWithError(err)
+	}
+	cfmt.Println("{{✓ Env vars of app " + app + " updated}}::green")
+	for _, change := range changes {
+		fmt.Println("    " + change)
+	}
+}
+
+func maskEnvValue(name string, value string) string {
+	if showSecrets || value == "" || !(sensitiveName.MatchString(name) || secretEnvName.MatchString(name)) {
+		return value
+	}
+	return "********"
+}
+
+// readDotenv reads NAME=value lines. Empty lines and comments are skipped,
+// an "export " prefix is allowed. Double quoted values may contain \n, \"
+// and \\ escapes, single quoted values are taken as they are. Both may span
+// lines.
+func readDotenv(file string) ([]kuberoapi.EnvVar, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var vars []kuberoapi.EnvVar
+	scanner := bufio.NewScanner(f)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	lineNumber := 0
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		name, value, found := strings.Cut(line, "=")
+		name = strings.TrimSpace(name)
+		if !found || !envNamePattern.MatchString(name) {
+			return nil, fmt.Errorf("%s:%d: expected NAME=value", file, lineNumber)
+		}
+		value = strings.TrimSpace(value)
+		start := lineNumber
+
+		if value != "" && (value[0] == '"' || value[0] == '\'') {
+			quote := value[0]
+			value = value[1:]
+			end := closingQuote(value, quote)
+			for end < 0 {
+				if !scanner.Scan() {
+					return nil, fmt.Errorf("%s:%d: missing closing %c", file, start, quote)
+				}
+				lineNumber++
+				value += "\n" + scanner.Text()
+				end = closingQuote(value, quote)
+			}
+			if rest := strings.TrimSpace(value[end+1:]); rest != "" && !strings.HasPrefix(rest, "#") {
+				return nil, fmt.Errorf("%s:%d: unexpected %q after the closing %c", file, lineNumber, rest, quote)
+			}
+			value = value[:end]
+			if quote == '"' {
+				value = unescapeDotenv(value)
+			}
+		} else if i := strings.Index(value, " #"); i >= 0 {
+			value = strings.TrimSpace(value[:i])
+		}
+
+		vars = append(vars, kuberoapi.EnvVar{Name: name, Value: value})
+	}
+	return vars, scanner.Err()
+}
+
+// closingQuote returns the index of the first unescaped quote in value, or
+// -1. Single quoted values have no escapes.
+func closingQuote(value string, quote byte) int {
+	for i := 0; i < len(value); i++ {
+		switch {
+		case value[i] == '\\' && quote == '"':
+			i++
+		case value[i] == quote:
+			return i
+		}
+	}
+	return -1
+}
+
+func unescapeDotenv(value string) string {
+	var b strings.Builder
+	for i := 0; i < len(value); i++ {
+		if value[i] != '\\' || i == len(value)-1 {
+			b.WriteByte(value[i])
+			continue
+		}
+		i++
+		switch value[i] {
+		case 'n':
+			b.WriteByte('\n')
+		case 't':
+			b.WriteByte('\t')
+		default:
+			b.WriteByte(value[i])
+		}
+	}
+	return b.String()
+}
+
+var plainDotenvValue = regexp.MustCompile(`^[A-Za-z0-9_./:@%+,=-]*$`)
+
+func quoteDotenv(value string) string {
+	if plainDotenvValue.MatchString(value) {
+		return value
+	}
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\t", `\t`)
+	return `"` + r.Replace(value) + `"`
+}
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"kubero/pkg/kuberoapi"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadDotenv(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []kuberoapi.EnvVar
+		err     string
+	}{
+		{
+			name:    "plain values",
+			content: "A=1\n\n# comment\nexport B = two words \nC=\n",
+			want:    []kuberoapi.EnvVar{{Name: "A", Value: "1"}, {Name: "B", Value: "two words"}, {Name: "C", Value: ""}},
+		},
+		{
+			name:    "inline comment",
+			content: "URL=http://example.com/#anchor # comment\n",
+			want:    []kuberoapi.EnvVar{{Name: "URL", Value: "http://example.com/#anchor"}},
+		},
+		{
+			name:    "double quotes with escapes",
+			content: `MSG="say \"hi\"\n\tbye \\ # not a comment" # comment` + "\n",
+			want:    []kuberoapi.EnvVar{{Name: "MSG", Value: "say \"hi\"\n\tbye \\ # not a comment"}},
+		},
+		{
+			name:    "single quotes without escapes",
+			content: `RAW='a\nb "c"'` + "\n",
+			want:    []kuberoapi.EnvVar{{Name: "RAW", Value: `a\nb "c"`}},
+		},
+		{
+			name:    "multi-line value",
+			content: "KEY=\"-----BEGIN-----\nabc\n-----END-----\"\nNEXT=1\n",
+			want:    []kuberoapi.EnvVar{{Name: "KEY", Value: "-----BEGIN-----\nabc\n-----END-----"}, {Name: "NEXT", Value: "1"}},
+		},
+		{
+			name:    "missing equal sign",
+			content: "A=1\nB\n",
+			err:     ".env:2: expected NAME=value",
+		},
+		{
+			name:    "invalid name",
+			content: "1A=1\n",
+			err:     ".env:1: expected NAME=value",
+		},
+		{
+			name:    "missing closing quote",
+			content: "A=1\nB='open\nC=2\n",
+			err:     ".env:2: missing closing '",
+		},
+		{
+			name:    "text after the closing quote",
+			content: `A="a" b` + "\n",
+			err:     `.env:1: unexpected "b" after the closing "`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file := filepath.Join(t.TempDir(), ".env")
+			if err := os.WriteFile(file, []byte(tt.content), 0600); err != nil {
+				t.Fatal(err)
+			}
+			got, err := readDotenv(file)
+			if tt.err != "" {
+				if err == nil || !strings.HasSuffix(err.Error(), tt.err) {
+					t.Errorf("err = %v, want %s", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestQuoteDotenv(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{"", ""},
+		{"postgres://user@db:5432/shop?ssl=1", `"postgres://user@db:5432/shop?ssl=1"`},
+		{"a-b_c.d/e:f@g%h+i,j=k", "a-b_c.d/e:f@g%h+i,j=k"},
+		{"two words", `"two words"`},
+		{"say \"hi\"\n\tbye \\", `"say \"hi\"\n\tbye \\"`},
+		{"#hash", `"#hash"`},
+	}
+
+	for _, tt := range tests {
+		got := quoteDotenv(tt.value)
+		if got != tt.want {
+			t.Errorf("quoteDotenv(%q) = %s, want %s", tt.value, got, tt.want)
+		}
+
+		// the quoted value reads back as the original value
+		file := filepath.Join(t.TempDir(), ".env")
+		os.WriteFile(file, []byte("NAME="+got+"\n"), 0600)
+		vars, err := readDotenv(file)
+		if err != nil || len(vars) != 1 || vars[0].Value != tt.value {
+			t.Errorf("reading %s back gave %q, %v", got, vars, err)
+		}
+	}
+}
@@ -1,8 +1,7 @@
 package cmd
 
 import (
-	"encoding/json"
-	"fmt"
+	"kubero/pkg/kuberoapi"
 
 	"github.com/i582/cfmt/cmd/cfmt"
 	"github.com/spf13/cobra"
@@ -15,15 +14,13 @@ var appsFetchCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 
 		app := appsFetchForm()
-		a, appErr := client.Get("/api/cli/pipelines/" + app.Spec.Pipeline + "/" + app.Spec.Phase + "/" + app.Spec.Name)
+		a, appErr := client.GetApp(cmd.Context(), app.Spec.Pipeline, app.Spec.Phase, app.Spec.Name)
 
 		if appErr != nil {
-			fmt.Println(appErr)
+			exitWithError(appErr)
 		} else {
-			fmt.Println(a)
 			cfmt.Println("{{App fetched successfully}}::green")
-			json.Unmarshal(a.Body(), &app)
-			fmt.Println(app)
+			app.Spec = a.Spec
 			writeAppYaml(app)
 		}
 	},
@@ -37,9 +34,9 @@ func init() {
 	appsCmd.AddCommand(appsFetchCmd)
 }
 
-func appsFetchForm() CreateApp {
+func appsFetchForm() kuberoapi.CreateApp {
 
-	var ca CreateApp
+	var ca kuberoapi.CreateApp
 	ca.APIVersion = "application.kubero.dev/v1alpha1"
 	ca.Kind = "KuberoApp"
 
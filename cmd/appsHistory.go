@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"context"
+	"kubero/pkg/kuberoapi"
+	"sort"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+var appsHistoryCmd = &cobra.Command{
+	Use:   "history",
+	Short: "List the past deployments of an app",
+	Long: `List the past deployments of an app, newest first. The release running now
+is marked with * in the CURRENT column.
+
+The pipeline, phase and app default to the ones in pipeline.yaml and
+app.<phase>.yaml of the current directory.`,
+	Example: `  kubero apps history -p shop -s production -a web`,
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := resolveApp(); err != nil {
+			exitWithError(err)
+		}
+		requireCapability(cmd.Context(), kuberoapi.CapabilityReleases)
+		current, err := client.GetApp(cmd.Context(), pipeline, stage, app)
+		if err != nil {
+			exitWithError(err)
+		}
+		releases := listReleases(cmd.Context())
+		printReleases(releases, currentRelease(releases, specImage(&current.Spec)))
+	},
+}
+
+func init() {
+	appsHistoryCmd.Flags().StringVarP(&pipeline, "pipeline", "p", "", "Name of the pipeline")
+	appsHistoryCmd.Flags().StringVarP(&stage, "stage", "s", "", "Name of the stage")
+	appsHistoryCmd.Flags().StringVarP(&app, "app", "a", "", "Name of the app")
+	addListFlags(appsHistoryCmd)
+	appsCmd.AddCommand(appsHistoryCmd)
+}
+
+// listReleases returns the releases of the app, newest first.
+func listReleases(ctx context.Context) []kuberoapi.Release {
+	releases, err := client.ListReleases(ctx, pipeline, stage, app)
+	if err != nil {
+		exitWithError(err)
+	}
+	sort.SliceStable(releases, func(i, j int) bool { return releases[i].DeployedAt.After(releases[j].DeployedAt) })
+	return releases
+}
+
+// currentRelease returns the name of the newest release of the image, which
+// may have been deployed several times.
+func currentRelease(releases []kuberoapi.Release, image string) string {
+	for _, release := range releases {
+		if release.Image == image {
+			return release.Name
+		}
+	}
+	return ""
+}
+
+// specImage returns the image an app is configured to run.
+func specImage(spec *kuberoapi.AppSpec) string {
+	if spec.Image.Repository == "" {
+		return ""
+	}
+	return spec.Image.Repository + ":" + spec.Image.Tag
+}
+
+func printReleases(releases []kuberoapi.Release, current string) {
+	items := make([]interface{}, len(releases))
+	for i, release := range releases {
+		items[i] = release
+	}
+
+	release := func(item interface{}) kuberoapi.Release { return item.(kuberoapi.Release) }
+	printList(outputList{
+		data:  releases,
+		items: items,
+		columns: []outputColumn{
+			{header: "Current", value: func(item interface{}) string {
+				if release(item).Name == current {
+					return "*"
+				}
+				return ""
+			}},
+			{header: "Release", value: func(item interface{}) string { return release(item).Name }},
+			{header: "Deployed", value: func(item interface{}) string {
+				return release(item).DeployedAt.Local().Format("2006-01-02 15:04")
+			}},
+			{header: "Commit", value: func(item interface{}) string { return shortCommit(release(item).Commit) }},
+			{header: "Image", value: func(item interface{}) string { return release(item).Image }},
+			{header: "Triggered by", value: func(item interface{}) string { return release(item).TriggeredBy }},
+			{header: "Build", value: func(item interface{}) string { return release(item).Status }},
+		},
+		name: func(item interface{}) string { return release(item).Name },
+		style: func(table *tablewriter.Table) {
+			table.SetBorder(false)
+		},
+		fields: map[string]string{
+			"deployed": ".deployedAt",
+			"build":    ".status",
+			"user":     ".triggeredBy",
+		},
+		dataOf: func(items []interface{}) interface{} {
+			filtered := make([]kuberoapi.Release, len(items))
+			for i, item := range items {
+				filtered[i] = release(item)
+			}
+			return filtered
+		},
+	})
+}
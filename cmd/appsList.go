@@ -1,11 +1,10 @@
 package cmd
 
 import (
-	"encoding/json"
+	"kubero/pkg/kuberoapi"
 	"os"
-	"strings"
+	"strconv"
 
-	"github.com/go-resty/resty/v2"
 	"github.com/i582/cfmt/cmd/cfmt"
 	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
@@ -31,52 +30,72 @@ to quickly create a Cobra application.`,
 			}
 		}
 
-		pipelineResp, _ := client.Get("/api/cli/pipelines/" + pipeline + "/apps")
-		printAppsList(pipelineResp)
+		pipelineApps, err := client.ListApps(cmd.Context(), pipeline)
+		if err != nil {
+			exitWithError(err)
+		}
+		printAppsList(pipelineApps)
 	},
 }
 
 func init() {
 	appsListCmd.Flags().StringVarP(&pipeline, "pipeline", "p", "", "Name of the Pipeline")
 	//appsListCmd.MarkFlagRequired("pipeline")
+	addListFlags(appsListCmd)
 	appsCmd.AddCommand(appsListCmd)
 }
 
-func printAppsList(r *resty.Response) {
-	//fmt.Println(r)
-
-	var pl Pipeline
-	json.Unmarshal(r.Body(), &pl)
-
+func printAppsList(pl *kuberoapi.Pipeline) {
+	items := []interface{}{}
 	for _, phase := range pl.Phases {
 		if !phase.Enabled {
 			continue
 		}
-
-		cfmt.Println("{{  " + strings.ToUpper(phase.Name) + "}}::bold|white")
-
-		table := tablewriter.NewWriter(os.Stdout)
-		table.SetHeader([]string{
-			"Name",
-			"Phase",
-			"Pipeline",
-			"Repository",
-			"Domain",
-		})
-		table.SetBorder(false)
-
 		for _, app := range phase.Apps {
-			table.Append([]string{
-				app.Name,
-				app.Phase,
-				app.Pipeline,
-				app.Gitrepo.CloneURL + ":" +
-					app.Gitrepo.DefaultBranch,
-				app.Domain,
-			})
+			items = append(items, app)
 		}
-
-		printCLI(table, r)
-		print("\n")
 	}
+
+	app := func(item interface{}) kuberoapi.App { return item.(kuberoapi.App) }
+	printList(outputList{
+		data:  pl,
+		items: items,
+		columns: []outputColumn{
+			{header: "Name", value: func(item interface{}) string { return app(item).Name }},
+			{header: "Phase", value: func(item interface{}) string { return app(item).Phase }},
+			{header: "Pipeline", value: func(item interface{}) string { return app(item).Pipeline }},
+			{header: "Repository", value: func(item interface{}) string {
+				return app(item).Gitrepo.CloneURL + ":" + app(item).Gitrepo.DefaultBranch
+			}},
+			{header: "Domain", value: func(item interface{}) string { return app(item).Domain }},
+			{header: "Branch", wide: true, value: func(item interface{}) string { return app(item).Branch }},
+			{header: "Podsize", wide: true, value: func(item interface{}) string { return app(item).Podsize }},
+			{header: "Web", wide: true, value: func(item interface{}) string { return strconv.Itoa(app(item).Web.ReplicaCount) }},
+			{header: "Worker", wide: true, value: func(item interface{}) string { return strconv.Itoa(app(item).Worker.ReplicaCount) }},
+			{header: "Autoscale", wide: true, value: func(item interface{}) string { return strconv.FormatBool(app(item).Autoscale) }},
+			{header: "Autodeploy", wide: true, value: func(item interface{}) string { return strconv.FormatBool(app(item).Autodeploy) }},
+		},
+		name: func(item interface{}) string { return app(item).Phase + "/" + app(item).Name },
+		style: func(table *tablewriter.Table) {
+			table.SetBorder(false)
+		},
+		fields: map[string]string{
+			"repository": ".gitrepo.clone_url",
+			"web":        ".web.replicaCount",
+			"worker":     ".worker.replicaCount",
+		},
+		dataOf: func(items []interface{}) interface{} {
+			filtered := *pl
+			filtered.Phases = append(filtered.Phases[:0:0], pl.Phases...)
+			for i := range filtered.Phases {
+				filtered.Phases[i].Apps = []kuberoapi.App{}
+				for _, item := range items {
+					if app(item).Phase == filtered.Phases[i].Name {
+						filtered.Phases[i].Apps = append(filtered.Phases[i].Apps, app(item))
+					}
+				}
+			}
+			return &filtered
+		},
+	})
 }
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"fmt"
+	"kubero/pkg/kuberoapi"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// time between two requests for new lines with --follow
+const logsPollInterval = 2 * time.Second
+
+var followLogs bool
+var logsSince time.Duration
+var logsTail int
+var logsContainer string
+
+var appsLogsCmd = &cobra.Command{
+	Use:   "logs",
+	Short: "Print the logs of an app",
+	Long: `Print the logs of all pods of an app, prefixed with the pod name.
+
+The pipeline, phase and app default to the ones in pipeline.yaml and
+app.<phase>.yaml of the current directory.`,
+	Example: `  kubero apps logs -p shop -s production -a web --tail 100
+  kubero apps logs -s production --follow --since 10m
+  kubero apps logs -s production --container build`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := resolveApp(); err != nil {
+			exitWithError(err)
+		}
+		requireCapability(cmd.Context(), kuberoapi.CapabilityLogs)
+		switch logsContainer {
+		case kuberoapi.ContainerWeb, kuberoapi.ContainerWorker, kuberoapi.ContainerBuild:
+		default:
+			exitWithError(fmt.Errorf("invalid --container %q, expected web, worker or build", logsContainer))
+		}
+
+		var since time.Time
+		if logsSince > 0 {
+			since = time.Now().Add(-logsSince)
+		}
+
+		seen := map[string]bool{}
+		first := true
+		for {
+			lines, err := client.GetLogs(cmd.Context(), pipeline, stage, app, logsContainer)
+			if err != nil {
+				exitWithError(err)
+			}
+			sort.SliceStable(lines, func(i, j int) bool {
+				return lines[i].Time < lines[j].Time
+			})
+
+			var fresh []kuberoapi.LogLine
+			for _, line := range lines {
+				if seen[logLineKey(line)] || line.Timestamp().Before(since) {
+					continue
+				}
+				fresh = append(fresh, line)
+			}
+			if first && logsTail >= 0 && len(fresh) > logsTail {
+				fresh = fresh[len(fresh)-logsTail:]
+			}
+			for _, line := range fresh {
+				printLogLine(line)
+			}
+
+			if !followLogs {
+				return
+			}
+			// the server only keeps the recent lines, so only those need to
+			// be remembered
+			seen = map[string]bool{}
+			for _, line := range lines {
+				seen[logLineKey(line)] = true
+			}
+			first = false
+			time.Sleep(logsPollInterval)
+		}
+	},
+}
+
+func init() {
+	appsLogsCmd.Flags().StringVarP(&pipeline, "pipeline", "p", "", "Name of the pipeline")
+	appsLogsCmd.Flags().StringVarP(&stage, "stage", "s", "", "Name of the stage")
+	appsLogsCmd.Flags().StringVarP(&app, "app", "a", "", "Name of the app")
+	appsLogsCmd.Flags().BoolVarP(&followLogs, "follow", "f", false, "Keep printing new lines")
+	appsLogsCmd.Flags().DurationVar(&logsSince, "since", 0, "Only print lines newer than this, e.g. 10m or 2h")
+	appsLogsCmd.Flags().IntVar(&logsTail, "tail", -1, "Number of recent lines to print, -1 for all")
+	appsLogsCmd.Flags().StringVarP(&logsContainer, "container", "c", kuberoapi.ContainerWeb, "Container to print the logs of [web,worker,build]")
+	appsCmd.AddCommand(appsLogsCmd)
+}
+
+func logLineKey(line kuberoapi.LogLine) string {
+	if line.ID != "" {
+		return line.ID
+	}
+	return fmt.Sprint(line.Time, line.Pod, line.Log)
+}
+
+func printLogLine(line kuberoapi.LogLine) {
+	prefix := "[" + line.Pod + "/" + line.Container + "]"
+	fmt.Println(colorize(prefix, "cyan") + " " + strings.TrimRight(line.Log, "\n"))
+}
@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"kubero/pkg/kuberoapi"
+	"os"
+	"strings"
+
+	"github.com/i582/cfmt/cmd/cfmt"
+	"github.com/spf13/cobra"
+)
+
+var promoteFrom string
+var promoteTo string
+
+var appsPromoteCmd = &cobra.Command{
+	Use:   "promote",
+	Short: "Promote an app to the next phase of its pipeline",
+	Long: `Copy an app to another phase of its pipeline, running the image that runs in
+the source phase.
+
+Git apps are pinned to the commit of the image, so later builds in the target
+phase build the same code. The domain, ingress hosts, env vars, replicas,
+autoscaling and pod size stay the ones of the target phase if the app already
+exists there. A new app gets no env vars, since they may hold secrets of the
+source phase. The domain,
+replicas and pod size in app.<to>.yaml override them. The changes are shown
+as diff and have to be confirmed, unless --force is given.`,
+	Example: `  kubero apps promote -p shop -a web --from stage --to production`,
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := cmd.Context()
+		if pipeline == "" {
+			pipeline = pipelineConfig.GetString("spec.name")
+		}
+		if app == "" {
+			app = loadAppConfig(promoteFrom).GetString("spec.name")
+		}
+		if pipeline == "" || app == "" {
+			exitWithError(fmt.Errorf("missing --pipeline or --app, not found in pipeline.yaml or app.%s.yaml", promoteFrom))
+		}
+		if promoteFrom == promoteTo {
+			exitWithError(fmt.Errorf("--from and --to are both %s", promoteFrom))
+		}
+
+		requireCapability(ctx, kuberoapi.CapabilityPods)
+
+		source, err := client.GetApp(ctx, pipeline, promoteFrom, app)
+		if err != nil {
+			exitWithError(err)
+		}
+		var target *kuberoapi.AppSpec
+		if existing, err := client.GetApp(ctx, pipeline, promoteTo, app); err == nil {
+			target = &existing.Spec
+		} else if !kuberoapi.IsNotFound(err) {
+			exitWithError(err)
+		}
+
+		spec := source.Spec
+		spec.Phase = promoteTo
+		// a promoted app runs the image of the source phase, not the latest
+		// commit of its branch
+		spec.Autodeploy = false
+		pods, err := client.ListPods(ctx, pipeline, promoteFrom, app)
+		if err != nil {
+			exitWithError(err)
+		}
+		image := runningImage(&appDescription{Spec: &source.Spec, Pods: pods})
+		if image == "" {
+			exitWithError(fmt.Errorf("app %s runs no image in %s", app, promoteFrom))
+		}
+		spec.Image.Repository, spec.Image.Tag = splitImage(image)
+		if spec.Deploymentstrategy != kuberoapi.StrategyDocker {
+			// later builds in the target phase build the promoted commit
+			commit, err := imageCommit(ctx, image)
+			if err != nil {
+				exitWithError(err)
+			}
+			if commit != "" {
+				spec.Branch = commit
+			} else {
+				fmt.Fprintln(os.Stderr, "  the commit of "+image+" is unknown, builds in "+promoteTo+" use branch "+spec.Branch)
+			}
+		}
+
+		if target != nil {
+			spec.Domain = target.Domain
+			spec.Ingress.Hosts = target.Ingress.Hosts
+			spec.EnvVars = target.EnvVars
+			spec.Podsize = target.Podsize
+			spec.Autoscale = target.Autoscale
+			spec.Autoscaling = target.Autoscaling
+			spec.Web = target.Web
+			spec.Worker = target.Worker
+		}
+		overrides := loadAppConfig(promoteTo)
+		if overrides.IsSet("spec.domain") {
+			spec.Domain = overrides.GetString("spec.domain")
+		}
+		if overrides.IsSet("spec.podsize") {
+			spec.Podsize = overrides.GetString("spec.podsize")
+		}
+		if overrides.IsSet("spec.web.replicacount") {
+			spec.Web.ReplicaCount = overrides.GetInt("spec.web.replicacount")
+		}
+		if overrides.IsSet("spec.worker.replicacount") {
+			spec.Worker.ReplicaCount = overrides.GetInt("spec.worker.replicacount")
+		}
+		if target == nil {
+			// the env vars of the source phase may hold its secrets
+			if len(spec.EnvVars) > 0 {
+				fmt.Fprintf(os.Stderr, "  %d env vars of %s are not copied, set them with 'kubero apps env set -s %s'\n", len(spec.EnvVars), promoteFrom, promoteTo)
+			}
+			spec.EnvVars = nil
+			if spec.Domain == source.Spec.Domain {
+				exitWithError(fmt.Errorf("the app does not exist in %s yet, set spec.domain in app.%s.yaml", promoteTo, promoteTo))
+			}
+			for i := range spec.Ingress.Hosts {
+				if spec.Ingress.Hosts[i].Host == source.Spec.Domain {
+					spec.Ingress.Hosts[i].Host = spec.Domain
+				}
+			}
+		}
+
+		m := &manifest{
+			file: "promoted from " + promoteFrom,
+			app:  &kuberoapi.CreateApp{APIVersion: source.APIVersion, Kind: kindApp, Spec: spec},
+		}
+		var live interface{}
+		if target != nil {
+			live = target
+		}
+		diff, err := manifestDiff(m, live)
+		if err != nil {
+			exitWithError(err)
+		}
+		if diff == "" {
+			fmt.Println("  app " + app + " in " + promoteTo + " is up to date")
+			return
+		}
+		printDiff(diff)
+
+		if !confirm(fmt.Sprintf("Promote %s from %s to %s?", app, promoteFrom, promoteTo)) {
+			fmt.Println("  aborted")
+			return
+		}
+		if target == nil {
+			_, err = client.CreateApp(ctx, &spec)
+		} else {
+			_, err = client.UpdateApp(ctx, pipeline, promoteTo, app, &spec)
+		}
+		if err != nil {
+			exitWithError(err)
+		}
+		cfmt.Println("{{✓ App " + app + " promoted from " + promoteFrom + " to " + promoteTo + "}}::green")
+	},
+}
+
+// imageCommit returns the commit an image of the app was built from, if a
+// release of the image tells.
+func imageCommit(ctx context.Context, image string) (string, error) {
+	if !serverSupports(ctx, kuberoapi.CapabilityReleases) {
+		return "", nil
+	}
+	releases, err := client.ListReleases(ctx, pipeline, promoteFrom, app)
+	if kuberoapi.IsUnsupported(err) || kuberoapi.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	for _, release := range releases {
+		if release.Image == image && release.Commit != "" {
+			return release.Commit, nil
+		}
+	}
+	return "", nil
+}
+
+func init() {
+	appsPromoteCmd.Flags().StringVarP(&pipeline, "pipeline", "p", "", "Name of the pipeline")
+	appsPromoteCmd.Flags().StringVarP(&app, "app", "a", "", "Name of the app")
+	appsPromoteCmd.Flags().StringVar(&promoteFrom, "from", "", "* Phase to promote from")
+	appsPromoteCmd.Flags().StringVar(&promoteTo, "to", "", "* Phase to promote to")
+	appsPromoteCmd.Flags().BoolVarP(&force, "force", "f", false, "Skip asking for confirmation")
+	appsPromoteCmd.MarkFlagRequired("from")
+	appsPromoteCmd.MarkFlagRequired("to")
+	appsCmd.AddCommand(appsPromoteCmd)
+}
+
+// splitImage splits an image reference into repository and tag or digest. A
+// port of the registry is part of the repository.
+func splitImage(image string) (string, string) {
+	if repository, digest, found := strings.Cut(image, "@"); found {
+		return repository, digest
+	}
+	i := strings.LastIndex(image, ":")
+	if i < 0 || strings.Contains(image[i:], "/") {
+		return image, "latest"
+	}
+	return image[:i], image[i+1:]
+}
@@ -0,0 +1,26 @@
+package cmd
+
+import "testing"
+
+func TestSplitImage(t *testing.T) {
+	tests := []struct {
+		image      string
+		repository string
+		tag        string
+	}{
+		{"nginx", "nginx", "latest"},
+		{"nginx:1.25", "nginx", "1.25"},
+		{"ghcr.io/acme/shop:1.4.2", "ghcr.io/acme/shop", "1.4.2"},
+		{"registry:5000/shop", "registry:5000/shop", "latest"},
+		{"registry:5000/shop:v2", "registry:5000/shop", "v2"},
+		{"ghcr.io/acme/shop@sha256:abc123", "ghcr.io/acme/shop", "sha256:abc123"},
+		{"registry:5000/shop@sha256:abc123", "registry:5000/shop", "sha256:abc123"},
+	}
+
+	for _, tt := range tests {
+		repository, tag := splitImage(tt.image)
+		if repository != tt.repository || tag != tt.tag {
+			t.Errorf("splitImage(%q) = %q, %q, want %q, %q", tt.image, repository, tag, tt.repository, tt.tag)
+		}
+	}
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"fmt"
+	"kubero/pkg/kuberoapi"
+
+	"github.com/i582/cfmt/cmd/cfmt"
+	"github.com/spf13/cobra"
+)
+
+var appsRestartCmd = &cobra.Command{
+	Use:   "restart",
+	Short: "Restart all pods of an app",
+	Long: `Restart all pods of an app one after another, without downtime.
+
+The pipeline, phase and app default to the ones in pipeline.yaml and
+app.<phase>.yaml of the current directory.`,
+	Example: `  kubero apps restart -p shop -s production -a web --wait`,
+	Args:    cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := resolveApp(); err != nil {
+			exitWithError(err)
+		}
+		if waitReady {
+			requireCapability(cmd.Context(), kuberoapi.CapabilityPods)
+		}
+
+		// pods running before the restart, which have to be replaced
+		old := map[string]bool{}
+		if waitReady {
+			pods, err := client.ListPods(cmd.Context(), pipeline, stage, app)
+			if err != nil {
+				exitWithError(err)
+			}
+			for _, pod := range pods {
+				old[pod.Name] = true
+			}
+		}
+
+		if err := client.RestartApp(cmd.Context(), pipeline, stage, app); err != nil {
+			exitWithError(err)
+		}
+		cfmt.Println("{{✓ Restart of app " + app + " triggered}}::green")
+
+		if waitReady {
+			waitForPods(cmd.Context(), "Waiting for the pods to be replaced", func(pods []kuberoapi.Pod) (bool, string) {
+				replaced := 0
+				for _, pod := range pods {
+					if !old[pod.Name] && pod.Ready() {
+						replaced++
+					}
+				}
+				return replaced > 0 && replaced == len(pods), fmt.Sprintf("%d/%d pods replaced", replaced, len(pods))
+			})
+		}
+	},
+}
+
+func init() {
+	appsRestartCmd.Flags().StringVarP(&pipeline, "pipeline", "p", "", "Name of the pipeline")
+	appsRestartCmd.Flags().StringVarP(&stage, "stage", "s", "", "Name of the stage")
+	appsRestartCmd.Flags().StringVarP(&app, "app", "a", "", "Name of the app")
+	addWaitFlags(appsRestartCmd)
+	appsCmd.AddCommand(appsRestartCmd)
+}
@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"kubero/pkg/kuberoapi"
+
+	"github.com/i582/cfmt/cmd/cfmt"
+	"github.com/spf13/cobra"
+)
+
+var rollbackTo string
+
+var appsRollbackCmd = &cobra.Command{
+	Use:   "rollback",
+	Short: "Deploy the image of a previous release of an app again",
+	Long: `Deploy the image of a previous release of an app again, without building it
+again. Without --to, the newest successful release deployed before the current
+image is deployed. Autodeploy is turned off, so the next push does
+not replace the rolled back release.
+
+The pipeline, phase and app default to the ones in pipeline.yaml and
+app.<phase>.yaml of the current directory.`,
+	Example: `  kubero apps rollback -p shop -s production -a web
+  kubero apps rollback -s production --to web-release-41 --force`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := resolveApp(); err != nil {
+			exitWithError(err)
+		}
+		requireCapability(cmd.Context(), kuberoapi.CapabilityReleases)
+		current, err := client.GetApp(cmd.Context(), pipeline, stage, app)
+		if err != nil {
+			exitWithError(err)
+		}
+		currentImage := specImage(&current.Spec)
+
+		var release *kuberoapi.Release
+		releases := listReleases(cmd.Context())
+		// without --to, only releases before the first deployment of the
+		// current image are candidates, so a second rollback doesn't bring
+		// back the release rolled back from
+		candidates := releases
+		if rollbackTo == "" {
+			for i := len(releases) - 1; i >= 0; i-- {
+				if releases[i].Image == currentImage {
+					candidates = releases[i+1:]
+					break
+				}
+			}
+		}
+		for i := range candidates {
+			r := &candidates[i]
+			if (rollbackTo != "" && r.Name == rollbackTo) ||
+				(rollbackTo == "" && r.Image != currentImage && r.Image != "" && r.Status != kuberoapi.BuildFailed) {
+				release = r
+				break
+			}
+		}
+		if release == nil && rollbackTo != "" {
+			exitWithError(fmt.Errorf("release %s of app %s not found, see 'kubero apps history'", rollbackTo, app))
+		} else if release == nil {
+			exitWithError(fmt.Errorf("no previous release of app %s to roll back to", app))
+		}
+		if release.Image == currentImage {
+			fmt.Println("  app " + app + " already runs release " + release.Name)
+			return
+		}
+
+		spec := current.Spec
+		spec.Image.Repository, spec.Image.Tag = splitImage(release.Image)
+		spec.Autodeploy = false
+		changes, err := specChanges(&current.Spec, &spec)
+		if err != nil {
+			exitWithError(err)
+		}
+		for _, change := range changes {
+			fmt.Println("    ~ " + change.String())
+		}
+
+		question := fmt.Sprintf("Roll back %s in %s to release %s, deployed %s?",
+			app, stage, release.Name, release.DeployedAt.Local().Format("2006-01-02 15:04"))
+		if !confirm(question) {
+			fmt.Println("  aborted")
+			return
+		}
+		if _, err := client.UpdateApp(cmd.Context(), pipeline, stage, app, &spec); err != nil {
+			exitWithError(err)
+		}
+		cfmt.Println("{{✓ App " + app + " rolled back to release " + release.Name + "}}::green")
+	},
+}
+
+func init() {
+	appsRollbackCmd.Flags().StringVarP(&pipeline, "pipeline", "p", "", "Name of the pipeline")
+	appsRollbackCmd.Flags().StringVarP(&stage, "stage", "s", "", "Name of the stage")
+	appsRollbackCmd.Flags().StringVarP(&app, "app", "a", "", "Name of the app")
+	appsRollbackCmd.Flags().StringVar(&rollbackTo, "to", "", "Name of the release to roll back to, see 'apps history'")
+	appsRollbackCmd.Flags().BoolVarP(&force, "force", "f", false, "Skip asking for confirmation")
+	appsCmd.AddCommand(appsRollbackCmd)
+}
@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"kubero/pkg/kuberoapi"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/i582/cfmt/cmd/cfmt"
+	"github.com/spf13/cobra"
+)
+
+// time between two requests for the pods with --wait
+const waitPollInterval = 2 * time.Second
+
+var webReplicas int
+var workerReplicas int
+var autoscale string
+var waitReady bool
+var waitTimeout time.Duration
+
+var appsScaleCmd = &cobra.Command{
+	Use:   "scale",
+	Short: "Change the number of web and worker pods of an app",
+	Long: `Change the number of web and worker pods of an app without the interactive
+form. Options not given are left unchanged.
+
+--autoscale min:max:cpu scales the web pods between min and max pods at the
+given CPU utilization in percent, --autoscale off turns autoscaling off again.
+
+The pipeline, phase and app default to the ones in pipeline.yaml and
+app.<phase>.yaml of the current directory.`,
+	Example: `  kubero apps scale -p shop -s production -a web --web 3 --worker 2
+  kubero apps scale -s production --autoscale 2:10:80 --wait`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := resolveApp(); err != nil {
+			exitWithError(err)
+		}
+		if waitReady {
+			requireCapability(cmd.Context(), kuberoapi.CapabilityPods)
+		}
+		flags := cmd.Flags()
+		if !flags.Changed("web") && !flags.Changed("worker") && !flags.Changed("autoscale") {
+			exitWithError(fmt.Errorf("nothing to change, use --web, --worker or --autoscale"))
+		}
+		if webReplicas < 0 || workerReplicas < 0 {
+			exitWithError(fmt.Errorf("invalid number of pods, --web and --worker must be 0 or more"))
+		}
+
+		current, err := client.GetApp(cmd.Context(), pipeline, stage, app)
+		if err != nil {
+			exitWithError(err)
+		}
+		spec := current.Spec
+
+		if flags.Changed("web") {
+			spec.Web.ReplicaCount = webReplicas
+		}
+		if flags.Changed("worker") {
+			spec.Worker.ReplicaCount = workerReplicas
+		}
+		if flags.Changed("autoscale") {
+			if err := setAutoscale(&spec, autoscale); err != nil {
+				exitWithError(err)
+			}
+		}
+
+		changes, err := specChanges(&current.Spec, &spec)
+		if err != nil {
+			exitWithError(err)
+		}
+		if len(changes) == 0 {
+			fmt.Println("  app " + pipeline + "/" + stage + "/" + app + " unchanged")
+			return
+		}
+
+		if _, err := client.UpdateApp(cmd.Context(), pipeline, stage, app, &spec); err != nil {
+			exitWithError(err)
+		}
+		cfmt.Println("{{✓ App " + app + " scaled}}::green")
+		for _, change := range changes {
+			fmt.Println("    ~ " + change.String())
+		}
+
+		if waitReady {
+			waitForPods(cmd.Context(), "Waiting for the pods to be ready", func(pods []kuberoapi.Pod) (bool, string) {
+				ready := readyPods(pods)
+				if spec.Autoscale {
+					return ready == len(pods) && ready >= spec.Web.Autoscaling.MinReplicas, fmt.Sprintf("%d pods ready", ready)
+				}
+				want := spec.Web.ReplicaCount + spec.Worker.ReplicaCount
+				return ready == want && len(pods) == want, fmt.Sprintf("%d/%d pods ready", ready, want)
+			})
+		}
+	},
+}
+
+func init() {
+	appsScaleCmd.Flags().StringVarP(&pipeline, "pipeline", "p", "", "Name of the pipeline")
+	appsScaleCmd.Flags().StringVarP(&stage, "stage", "s", "", "Name of the stage")
+	appsScaleCmd.Flags().StringVarP(&app, "app", "a", "", "Name of the app")
+	appsScaleCmd.Flags().IntVar(&webReplicas, "web", 0, "Number of web pods")
+	appsScaleCmd.Flags().IntVar(&workerReplicas, "worker", 0, "Number of worker pods")
+	appsScaleCmd.Flags().StringVar(&autoscale, "autoscale", "", "Autoscale the web pods, as min:max:cpu% or off")
+	addWaitFlags(appsScaleCmd)
+	appsCmd.AddCommand(appsScaleCmd)
+}
+
+func addWaitFlags(cmd *cobra.Command) {
+	cmd.Flags().BoolVarP(&waitReady, "wait", "w", false, "Wait until the pods are ready")
+	cmd.Flags().DurationVar(&waitTimeout, "wait-timeout", 5*time.Minute, "Maximum time to wait with --wait")
+}
+
+// setAutoscale parses min:max:cpu% or off.
+func setAutoscale(spec *kuberoapi.AppSpec, value string) error {
+	if value == "off" {
+		spec.Autoscale = false
+		spec.Autoscaling.Enabled = false
+		return nil
+	}
+
+	parts := strings.Split(strings.TrimSuffix(value, "%"), ":")
+	numbers := make([]int, len(parts))
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			numbers = nil
+			break
+		}
+		numbers[i] = n
+	}
+	if len(numbers) != 3 || numbers[0] == 0 || numbers[0] > numbers[1] || numbers[2] == 0 || numbers[2] > 100 {
+		return fmt.Errorf("invalid --autoscale %q, expected min:max:cpu%%, e.g. 2:10:80, or off", value)
+	}
+
+	spec.Autoscale = true
+	spec.Autoscaling.Enabled = true
+	spec.Web.Autoscaling.MinReplicas = numbers[0]
+	spec.Web.Autoscaling.MaxReplicas = numbers[1]
+	spec.Web.Autoscaling.TargetCPUUtilizationPercentage = numbers[2]
+	return nil
+}
+
+func readyPods(pods []kuberoapi.Pod) int {
+	ready := 0
+	for i := range pods {
+		if pods[i].Ready() {
+			ready++
+		}
+	}
+	return ready
+}
+
+// waitForPods polls the pods of the app until done reports true and exits
+// if that takes longer than --wait-timeout. done also returns the progress
+// shown meanwhile.
+func waitForPods(ctx context.Context, message string, done func([]kuberoapi.Pod) (bool, string)) {
+	spinner := newSpinner(message)
+	spinner.Start()
+
+	deadline := time.Now().Add(waitTimeout)
+	last := ""
+	for {
+		pods, err := client.ListPods(ctx, pipeline, stage, app)
+		if err != nil {
+			spinner.Error(message)
+			exitWithError(err)
+		}
+		finished, status := done(pods)
+		if finished {
+			spinner.Success("Pods ready: " + status)
+			return
+		}
+		if status != last {
+			spinner.UpdateMessage(message + ": " + status)
+			last = status
+		}
+		if time.Now().After(deadline) {
+			spinner.Error(message + ": " + status)
+			exitWithError(fmt.Errorf("the pods were not ready after %s", waitTimeout))
+		}
+		time.Sleep(waitPollInterval)
+	}
+}
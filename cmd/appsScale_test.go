@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"kubero/pkg/kuberoapi"
+	"testing"
+)
+
+func TestSetAutoscale(t *testing.T) {
+	tests := []struct {
+		value   string
+		enabled bool
+		min     int
+		max     int
+		cpu     int
+		wantErr bool
+	}{
+		{value: "2:10:80%", enabled: true, min: 2, max: 10, cpu: 80},
+		{value: "1:1:100", enabled: true, min: 1, max: 1, cpu: 100},
+		{value: "off", enabled: false, min: 1, max: 5, cpu: 70},
+		{value: "0:10:80", wantErr: true},
+		{value: "5:2:80", wantErr: true},
+		{value: "2:10:0", wantErr: true},
+		{value: "2:10:101", wantErr: true},
+		{value: "2:10", wantErr: true},
+		{value: "2:10:80:1", wantErr: true},
+		{value: "-1:10:80", wantErr: true},
+		{value: "a:b:c", wantErr: true},
+		{value: "on", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		var spec kuberoapi.AppSpec
+		spec.Autoscale = true
+		spec.Autoscaling.Enabled = true
+		spec.Web.Autoscaling.MinReplicas = 1
+		spec.Web.Autoscaling.MaxReplicas = 5
+		spec.Web.Autoscaling.TargetCPUUtilizationPercentage = 70
+
+		err := setAutoscale(&spec, tt.value)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("setAutoscale(%q) succeeded", tt.value)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("setAutoscale(%q): %v", tt.value, err)
+			continue
+		}
+		autoscaling := spec.Web.Autoscaling
+		if spec.Autoscale != tt.enabled || spec.Autoscaling.Enabled != tt.enabled ||
+			autoscaling.MinReplicas != tt.min || autoscaling.MaxReplicas != tt.max || autoscaling.TargetCPUUtilizationPercentage != tt.cpu {
+			t.Errorf("setAutoscale(%q) = %v %d:%d:%d", tt.value, spec.Autoscale, autoscaling.MinReplicas, autoscaling.MaxReplicas, autoscaling.TargetCPUUtilizationPercentage)
+		}
+	}
+}
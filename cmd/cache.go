@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"kubero/pkg/kuberoapi"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/i582/cfmt/cmd/cfmt"
+	"github.com/spf13/cobra"
+)
+
+// default time the server configuration is used without asking the server
+const defaultCacheTTL = time.Hour
+
+var refreshCache bool
+var clearAllCaches bool
+
+var cacheCmd = &cobra.Command{
+	Use:   "cache",
+	Short: "Manage the local cache of the server configuration",
+	Long: `The buildpacks, pod sizes, addons, repositories, contexts and the detected
+capabilities of the server are cached per profile in $HOME/.kubero/cache.
+
+Cached entries are used for api.cacheTTL (default 1h) and revalidated with the
+server afterwards. --refresh ignores the cache for a single command.`,
+	// clearing a cache must work with a broken profile
+	PersistentPreRun: preRunWithoutClient,
+}
+
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Delete the cache of the active profile",
+	Args:  cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		dir := cacheDir()
+		if clearAllCaches {
+			dir = filepath.Dir(dir)
+		}
+		if err := os.RemoveAll(dir); err != nil {
+			exitWithError(err)
+		}
+		if clearAllCaches {
+			cfmt.Println("{{Cleared the cache of all profiles}}::green")
+		} else {
+			cfmt.Println("{{Cleared the cache of profile " + credentialName(activeProfile()) + "}}::green")
+		}
+	},
+}
+
+func init() {
+	rootCmd.PersistentFlags().BoolVar(&refreshCache, "refresh", false, "Ignore the cached server configuration")
+	cacheClearCmd.Flags().BoolVar(&clearAllCaches, "all", false, "Delete the cache of all profiles")
+	cacheCmd.AddCommand(cacheClearCmd)
+	rootCmd.AddCommand(cacheCmd)
+}
+
+// cacheDir returns the cache directory of the active profile.
+func cacheDir() string {
+	return filepath.Join(filepath.Dir(personalConfigFile()), "cache", credentialName(activeProfile()))
+}
+
+func cacheTTL() time.Duration {
+	ttl := configString("api.cacheTTL")
+	if ttl == "" {
+		return defaultCacheTTL
+	}
+	d, err := time.ParseDuration(ttl)
+	if err != nil {
+		exitWithError(fmt.Errorf("invalid api.cacheTTL: %w", err))
+	}
+	return d
+}
+
+// diskCache keeps one JSON file per cached response. Errors are ignored, a
+// broken cache only makes the CLI ask the server again.
+type diskCache struct {
+	dir     string
+	refresh bool
+}
+
+func (c *diskCache) file(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(hash[:8])+".json")
+}
+
+func (c *diskCache) Get(key string) (*kuberoapi.CacheEntry, bool) {
+	if c.refresh {
+		return nil, false
+	}
+	data, err := os.ReadFile(c.file(key))
+	if err != nil {
+		return nil, false
+	}
+	var entry kuberoapi.CacheEntry
+	if json.Unmarshal(data, &entry) != nil {
+		return nil, false
+	}
+	return &entry, true
+}
+
+func (c *diskCache) Set(key string, entry *kuberoapi.CacheEntry) {
+	data, err := json.Marshal(entry)
+	if err != nil || os.MkdirAll(c.dir, 0700) != nil {
+		return
+	}
+	os.WriteFile(c.file(key), data, 0600)
+}
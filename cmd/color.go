@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/i582/cfmt/cmd/cfmt"
+	"github.com/leaanthony/spinner"
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+)
+
+var noColor bool
+
+// fancyOutput is true if colors and spinners are used. It is false when
+// writing to a pipe or file, e.g. in CI logs.
+var fancyOutput = true
+
+func init() {
+	rootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable colors and spinners (env NO_COLOR)")
+	cobra.OnInitialize(setupColor)
+}
+
+// setupColor disables colors and spinners if asked to with --no-color,
+// NO_COLOR (https://no-color.org) or TERM=dumb, or if stdout or stderr is no
+// terminal.
+func setupColor() {
+	fancyOutput = !noColor &&
+		os.Getenv("NO_COLOR") == "" &&
+		os.Getenv("TERM") != "dumb" &&
+		term.IsTerminal(int(os.Stdout.Fd())) &&
+		term.IsTerminal(int(os.Stderr.Fd()))
+
+	if !fancyOutput {
+		cfmt.DisableColors()
+	}
+}
+
+// progress reports a long running step. It is a spinner on terminals and
+// prints one line per state otherwise.
+type progress interface {
+	Start(message ...string)
+	UpdateMessage(message string)
+	Success(message ...string)
+	Error(message ...string)
+}
+
+func newSpinner(message ...string) progress {
+	if fancyOutput {
+		return spinner.New(message...)
+	}
+	p := &plainProgress{}
+	if len(message) > 0 {
+		p.message = message[0]
+	}
+	return p
+}
+
+type plainProgress struct {
+	message string
+}
+
+func (p *plainProgress) Start(message ...string) {
+	if len(message) > 0 {
+		p.message = message[0]
+	}
+	fmt.Println("- " + p.message)
+}
+
+// UpdateMessage only prints changed messages, since it is called in polling
+// loops.
+func (p *plainProgress) UpdateMessage(message string) {
+	if message == p.message {
+		return
+	}
+	p.message = message
+	fmt.Println("- " + p.message)
+}
+
+func (p *plainProgress) Success(message ...string) {
+	if len(message) > 0 {
+		p.message = message[0]
+	}
+	fmt.Println("✓ " + p.message)
+}
+
+func (p *plainProgress) Error(message ...string) {
+	if len(message) > 0 {
+		p.message = message[0]
+	}
+	fmt.Println("✗ " + p.message)
+}
+
+// colorize colors text which may contain cfmt markup, e.g. lines of a diff.
+func colorize(text string, color string) string {
+	codes := map[string]string{"red": "31", "green": "32", "cyan": "36"}
+	if !fancyOutput || codes[color] == "" {
+		return text
+	}
+	return "\x1b[" + codes[color] + "m" + text + "\x1b[0m"
+}
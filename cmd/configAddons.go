@@ -1,11 +1,9 @@
 package cmd
 
 import (
-	"encoding/json"
-	"os"
+	"kubero/pkg/kuberoapi"
 	"strconv"
 
-	"github.com/go-resty/resty/v2"
 	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 )
@@ -15,9 +13,12 @@ var addonsCmd = &cobra.Command{
 	Use:   "addons",
 	Short: "A brief description of your command",
 	Run: func(cmd *cobra.Command, args []string) {
-		resp, _ := client.Get("/api/cli/addons")
-		//fmt.Println(resp)
-		printAddons(resp)
+		requireCapability(cmd.Context(), kuberoapi.CapabilityAddons)
+		addonsList, err := client.ListAddons(cmd.Context())
+		if err != nil {
+			exitWithError(err)
+		}
+		printAddons(addonsList)
 	},
 }
 
@@ -25,35 +26,29 @@ func init() {
 	configCmd.AddCommand(addonsCmd)
 }
 
-type AddonsList []struct {
-	ID      string `json:"id"`
-	Enabled bool   `json:"enabled"`
-	Version struct {
-		Latest    string `json:"latest"`
-		Installed string `json:"installed"`
-	} `json:"version,omitempty"`
-	Description string `json:"description,omitempty"`
-	Readme      string `json:"readme,omitempty"`
-	ArtifactURL string `json:"artifact_url"`
-	Kind        string `json:"kind"`
-	Install     string `json:"install"`
-	Beta        bool   `json:"beta"`
-}
-
 // print the response as a table
-func printAddons(r *resty.Response) {
-
-	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"Name", "Description", "Version", "Beta", "Enabled"})
-	table.SetRowLine(true)
-	//table.SetBorder(false)
-
-	var addonsList AddonsList
-	json.Unmarshal(r.Body(), &addonsList)
-
-	for _, addon := range addonsList {
-		table.Append([]string{addon.ID, addon.Description, addon.Version.Installed, strconv.FormatBool(addon.Beta), strconv.FormatBool(addon.Enabled)})
+func printAddons(addonsList kuberoapi.AddonsList) {
+	items := make([]interface{}, len(addonsList))
+	for i, addon := range addonsList {
+		items[i] = addon
 	}
 
-	printCLI(table, r)
+	addon := func(item interface{}) kuberoapi.Addon { return item.(kuberoapi.Addon) }
+	printList(outputList{
+		data:  addonsList,
+		items: items,
+		columns: []outputColumn{
+			{header: "Name", value: func(item interface{}) string { return addon(item).ID }},
+			{header: "Description", value: func(item interface{}) string { return addon(item).Description }},
+			{header: "Version", value: func(item interface{}) string { return addon(item).Version.Installed }},
+			{header: "Latest", wide: true, value: func(item interface{}) string { return addon(item).Version.Latest }},
+			{header: "Kind", wide: true, value: func(item interface{}) string { return addon(item).Kind }},
+			{header: "Beta", value: func(item interface{}) string { return strconv.FormatBool(addon(item).Beta) }},
+			{header: "Enabled", value: func(item interface{}) string { return strconv.FormatBool(addon(item).Enabled) }},
+		},
+		name: func(item interface{}) string { return addon(item).ID },
+		style: func(table *tablewriter.Table) {
+			table.SetRowLine(true)
+		},
+	})
 }
@@ -1,10 +1,9 @@
 package cmd
 
 import (
-	"encoding/json"
-	"os"
+	"context"
+	"kubero/pkg/kuberoapi"
 
-	"github.com/go-resty/resty/v2"
 	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 )
@@ -14,8 +13,12 @@ var buildpacksCmd = &cobra.Command{
 	Use:   "buildpacks",
 	Short: "List the available buildpacks",
 	Run: func(cmd *cobra.Command, args []string) {
-		resp, _ := client.Get("/api/cli/config/buildpacks")
-		printBuildpacks(resp)
+		requireCapability(cmd.Context(), kuberoapi.CapabilityBuildpacks)
+		buildPacksList, err := client.ListBuildpacks(cmd.Context())
+		if err != nil {
+			exitWithError(err)
+		}
+		printBuildpacks(buildPacksList)
 	},
 }
 
@@ -25,36 +28,16 @@ func init() {
 
 var buildPacksSimpleList []string
 
-type buildPacks []struct {
-	Name     string `json:"name"`
-	Language string `json:"language"`
-	Fetch    struct {
-		Repository string `json:"repository"`
-		Tag        string `json:"tag"`
-	} `json:"fetch"`
-	Build struct {
-		Repository string `json:"repository"`
-		Tag        string `json:"tag"`
-		Command    string `json:"command"`
-	} `json:"build"`
-	Run struct {
-		Repository         string `json:"repository"`
-		Tag                string `json:"tag"`
-		ReadOnlyAppStorage bool   `json:"readOnlyAppStorage"`
-		SecurityContext    *struct {
-			AllowPrivilegeEscalation *bool `json:"allowPrivilegeEscalation"`
-			ReadOnlyRootFilesystem   *bool `json:"readOnlyRootFilesystem"`
-		} `json:"securityContext"`
-		Command string `json:"command"`
-	} `json:"run,omitempty"`
-}
-
-func loadBuildpacks() {
-
-	b, _ := client.Get("/api/cli/config/buildpacks")
+func loadBuildpacks(ctx context.Context) {
+	if !serverSupports(ctx, kuberoapi.CapabilityBuildpacks) {
+		printUnsupported("Listing the buildpacks")
+		return
+	}
 
-	var buildPacks buildPacks
-	json.Unmarshal(b.Body(), &buildPacks)
+	buildPacks, err := client.ListBuildpacks(ctx)
+	if err != nil {
+		exitWithError(err)
+	}
 
 	for _, buildPack := range buildPacks {
 		buildPacksSimpleList = append(buildPacksSimpleList, buildPack.Name)
@@ -64,40 +47,34 @@ func loadBuildpacks() {
 }
 
 // print the response as a table
-func printBuildpacks(r *resty.Response) {
-
-	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"Name", "Language", "Phase", "Image", "Command"})
-	table.SetAutoMergeCells(true)
-	table.SetRowLine(true)
-	//table.SetBorder(false)
-
-	var buildPacksList buildPacks
-	json.Unmarshal(r.Body(), &buildPacksList)
-
-	for _, podsize := range buildPacksList {
-		table.Append([]string{
-			podsize.Name,
-			podsize.Language,
-			"Fetch",
-			podsize.Fetch.Repository + ":" + podsize.Fetch.Tag,
-			"git clone",
-		})
-		table.Append([]string{
-			podsize.Name,
-			podsize.Language,
-			"Build",
-			podsize.Build.Repository + ":" + podsize.Build.Tag,
-			podsize.Build.Command,
-		})
-		table.Append([]string{
-			podsize.Name,
-			podsize.Language,
-			"Run",
-			podsize.Run.Repository + ":" + podsize.Run.Tag + " (ro)",
-			podsize.Run.Command,
-		})
+func printBuildpacks(buildPacksList kuberoapi.Buildpacks) {
+	items := make([]interface{}, len(buildPacksList))
+	for i, buildpack := range buildPacksList {
+		items[i] = buildpack
 	}
 
-	printCLI(table, r)
+	buildpack := func(item interface{}) kuberoapi.Buildpack { return item.(kuberoapi.Buildpack) }
+	printList(outputList{
+		data:  buildPacksList,
+		items: items,
+		columns: []outputColumn{
+			{header: "Name", value: func(item interface{}) string { return buildpack(item).Name }},
+			{header: "Language", value: func(item interface{}) string { return buildpack(item).Language }},
+			{header: "Fetch", wide: true, value: func(item interface{}) string {
+				return buildpack(item).Fetch.Repository + ":" + buildpack(item).Fetch.Tag
+			}},
+			{header: "Build", value: func(item interface{}) string {
+				return buildpack(item).Build.Repository + ":" + buildpack(item).Build.Tag
+			}},
+			{header: "Build Command", wide: true, value: func(item interface{}) string { return buildpack(item).Build.Command }},
+			{header: "Run", value: func(item interface{}) string {
+				return buildpack(item).Run.Repository + ":" + buildpack(item).Run.Tag
+			}},
+			{header: "Run Command", wide: true, value: func(item interface{}) string { return buildpack(item).Run.Command }},
+		},
+		name: func(item interface{}) string { return buildpack(item).Name },
+		style: func(table *tablewriter.Table) {
+			table.SetRowLine(true)
+		},
+	})
 }
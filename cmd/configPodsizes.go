@@ -1,11 +1,9 @@
 package cmd
 
 import (
-	"encoding/json"
-	"os"
+	"kubero/pkg/kuberoapi"
+	"strconv"
 
-	"github.com/go-resty/resty/v2"
-	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 )
 
@@ -14,8 +12,12 @@ var podsizesCmd = &cobra.Command{
 	Use:   "podsizes",
 	Short: "List the available pod sizes",
 	Run: func(cmd *cobra.Command, args []string) {
-		resp, _ := client.Get("/api/cli/config/podsize")
-		printPodsizes(resp)
+		requireCapability(cmd.Context(), kuberoapi.CapabilityPodsizes)
+		podsizeList, err := client.ListPodsizes(cmd.Context())
+		if err != nil {
+			exitWithError(err)
+		}
+		printPodsizes(podsizeList)
 	},
 }
 
@@ -23,36 +25,28 @@ func init() {
 	configCmd.AddCommand(podsizesCmd)
 }
 
-type PodsizeList []struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
-	Default     bool   `json:"default,omitempty"`
-	Resources   struct {
-		Requests struct {
-			Memory string `json:"memory"`
-			CPU    string `json:"cpu"`
-		} `json:"requests"`
-		Limits struct {
-			Memory string `json:"memory"`
-			CPU    string `json:"cpu"`
-		} `json:"limits,omitempty"`
-	} `json:"resources,omitempty"`
-	Active bool `json:"active,omitempty"`
-}
-
 // print the response as a table
-func printPodsizes(r *resty.Response) {
-
-	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{"Name", "Description"})
-	//table.SetBorder(false)
-
-	var podsizeList PodsizeList
-	json.Unmarshal(r.Body(), &podsizeList)
-
-	for _, podsize := range podsizeList {
-		table.Append([]string{podsize.Name, podsize.Description})
+func printPodsizes(podsizeList kuberoapi.PodsizeList) {
+	items := make([]interface{}, len(podsizeList))
+	for i, podsize := range podsizeList {
+		items[i] = podsize
 	}
 
-	printCLI(table, r)
+	podsize := func(item interface{}) kuberoapi.Podsize { return item.(kuberoapi.Podsize) }
+	printList(outputList{
+		data:  podsizeList,
+		items: items,
+		columns: []outputColumn{
+			{header: "Name", value: func(item interface{}) string { return podsize(item).Name }},
+			{header: "Description", value: func(item interface{}) string { return podsize(item).Description }},
+			{header: "CPU", wide: true, value: func(item interface{}) string {
+				return podsize(item).Resources.Requests.CPU + " / " + podsize(item).Resources.Limits.CPU
+			}},
+			{header: "Memory", wide: true, value: func(item interface{}) string {
+				return podsize(item).Resources.Requests.Memory + " / " + podsize(item).Resources.Limits.Memory
+			}},
+			{header: "Default", wide: true, value: func(item interface{}) string { return strconv.FormatBool(podsize(item).Default) }},
+		},
+		name: func(item interface{}) string { return podsize(item).Name },
+	})
 }
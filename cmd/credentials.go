@@ -0,0 +1,210 @@
+package cmd
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// The built-in credential store keeps the API tokens of all profiles in one
+// AES-GCM encrypted file. The key is derived from KUBERO_CREDENTIALS_PASSPHRASE.
+// With api.credentialStore set to "keyfile" it is derived from a random key
+// file next to the store instead. Whoever can read the store can read that
+// file as well, so this only obfuscates the tokens.
+
+const credentialStoreMagic = "KCS1"
+
+// tokens saved without a profile are stored under this name
+const defaultCredentialName = "default"
+
+func credentialStoreFile() string {
+	return filepath.Join(filepath.Dir(personalConfigFile()), "credentials")
+}
+
+func credentialKeyFile() string {
+	return credentialStoreFile() + ".key"
+}
+
+func credentialName(profile string) string {
+	if profile == "" {
+		return defaultCredentialName
+	}
+	return profile
+}
+
+// credentialStoreKeyFile is the api.credentialStore value selecting the key
+// file instead of the passphrase.
+const credentialStoreKeyFile = "keyfile"
+
+var errNoCredentialPassphrase = errors.New("KUBERO_CREDENTIALS_PASSPHRASE is not set; set it to encrypt the credential store, " +
+	"set api.credentialStore to \"keyfile\" to only obfuscate the tokens with a key file, or use api.tokenCommand")
+
+// credentialSecret returns the secret the store key is derived from. The
+// key file is only created when create is set.
+func credentialSecret(create bool) ([]byte, error) {
+	if passphrase := os.Getenv("KUBERO_CREDENTIALS_PASSPHRASE"); passphrase != "" {
+		return []byte(passphrase), nil
+	}
+	if configString("api.credentialStore") != credentialStoreKeyFile {
+		return nil, errNoCredentialPassphrase
+	}
+
+	secret, err := os.ReadFile(credentialKeyFile())
+	if err == nil || !errors.Is(err, os.ErrNotExist) || !create {
+		return secret, err
+	}
+
+	secret = make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(credentialKeyFile()), 0700); err != nil {
+		return nil, err
+	}
+	return secret, os.WriteFile(credentialKeyFile(), secret, 0600)
+}
+
+func credentialCipher(secret []byte, salt []byte) (cipher.AEAD, error) {
+	key, err := scrypt.Key(secret, salt, 1<<15, 8, 1, 32)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// readCredentials decrypts the credential store. A missing store is empty.
+func readCredentials() (map[string]string, error) {
+	credentials := map[string]string{}
+
+	data, err := os.ReadFile(credentialStoreFile())
+	if errors.Is(err, os.ErrNotExist) {
+		return credentials, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	if !bytes.HasPrefix(data, []byte(credentialStoreMagic)) || len(data) < len(credentialStoreMagic)+16 {
+		return nil, fmt.Errorf("%s is not a kubero credential store", credentialStoreFile())
+	}
+	data = data[len(credentialStoreMagic):]
+	salt, data := data[:16], data[16:]
+
+	secret, err := credentialSecret(false)
+	if err != nil {
+		return nil, fmt.Errorf("reading the key of the credential store: %w", err)
+	}
+	aead, err := credentialCipher(secret, salt)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) < aead.NonceSize() {
+		return nil, fmt.Errorf("%s is corrupt", credentialStoreFile())
+	}
+	plain, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], nil)
+	if err != nil {
+		return nil, fmt.Errorf("can't decrypt %s, check KUBERO_CREDENTIALS_PASSPHRASE", credentialStoreFile())
+	}
+
+	err = json.Unmarshal(plain, &credentials)
+	return credentials, err
+}
+
+func writeCredentials(credentials map[string]string) error {
+	plain, err := json.Marshal(credentials)
+	if err != nil {
+		return err
+	}
+
+	secret, err := credentialSecret(true)
+	if err != nil {
+		return err
+	}
+	salt := make([]byte, 16)
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+	aead, err := credentialCipher(secret, salt)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+
+	data := []byte(credentialStoreMagic)
+	data = append(data, salt...)
+	data = append(data, nonce...)
+	data = aead.Seal(data, nonce, plain, nil)
+
+	if err := os.MkdirAll(filepath.Dir(credentialStoreFile()), 0700); err != nil {
+		return err
+	}
+	return os.WriteFile(credentialStoreFile(), data, 0600)
+}
+
+func storedToken(profile string) (string, error) {
+	credentials, err := readCredentials()
+	if err != nil {
+		return "", err
+	}
+	return credentials[credentialName(profile)], nil
+}
+
+func storeToken(profile string, token string) error {
+	credentials, err := readCredentials()
+	if err != nil {
+		return err
+	}
+	credentials[credentialName(profile)] = token
+	return writeCredentials(credentials)
+}
+
+func eraseToken(profile string) error {
+	credentials, err := readCredentials()
+	if err != nil {
+		return err
+	}
+	if _, ok := credentials[credentialName(profile)]; !ok {
+		return nil
+	}
+	delete(credentials, credentialName(profile))
+	return writeCredentials(credentials)
+}
+
+// runTokenCommand runs the api.tokenCommand in a shell and returns its
+// output as token. The profile is passed in KUBERO_PROFILE, so one command
+// can serve all profiles.
+func runTokenCommand(command string, profile string) (string, error) {
+	shell := exec.Command("sh", "-c", command)
+	if runtime.GOOS == "windows" {
+		shell = exec.Command("cmd", "/C", command)
+	}
+	shell.Env = append(os.Environ(), "KUBERO_PROFILE="+credentialName(profile))
+	shell.Stderr = os.Stderr
+
+	out, err := shell.Output()
+	if err != nil {
+		return "", fmt.Errorf("api.tokenCommand '%s' failed: %w", command, err)
+	}
+	token := strings.TrimSpace(string(out))
+	if token == "" {
+		return "", fmt.Errorf("api.tokenCommand '%s' returned no token", command)
+	}
+	return token, nil
+}
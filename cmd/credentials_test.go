@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestCredentialStore(t *testing.T) {
+	tests := []struct {
+		name       string
+		passphrase string
+		store      string
+		keyFile    bool
+		err        error
+	}{
+		{name: "passphrase", passphrase: "secret"},
+		{name: "passphrase wins over the key file", passphrase: "secret", store: credentialStoreKeyFile},
+		{name: "key file", store: credentialStoreKeyFile, keyFile: true},
+		{name: "neither", err: errNoCredentialPassphrase},
+	}
+
+	defer viper.Reset()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Setenv("HOME", t.TempDir())
+			t.Setenv("KUBERO_PROFILE", "")
+			t.Setenv("KUBERO_CREDENTIALS_PASSPHRASE", tt.passphrase)
+			viper.Reset()
+			viper.Set("api.credentialStore", tt.store)
+
+			err := storeToken("staging", "token-1")
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("err = %v, want %v", err, tt.err)
+			}
+			if tt.err != nil {
+				return
+			}
+			if _, err := os.Stat(credentialKeyFile()); (err == nil) != tt.keyFile {
+				t.Errorf("key file exists: %v, want %v", err == nil, tt.keyFile)
+			}
+			if token, err := storedToken("staging"); err != nil || token != "token-1" {
+				t.Errorf("stored token = %q, %v", token, err)
+			}
+			if token, err := storedToken(""); err != nil || token != "" {
+				t.Errorf("token of the default profile = %q, %v", token, err)
+			}
+		})
+	}
+}
+
+func TestCredentialStoreWrongPassphrase(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+	t.Setenv("KUBERO_CREDENTIALS_PASSPHRASE", "secret")
+	if err := storeToken("", "token-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("KUBERO_CREDENTIALS_PASSPHRASE", "wrong")
+	if _, err := storedToken(""); err == nil {
+		t.Error("token decrypted with the wrong passphrase")
+	}
+	t.Setenv("KUBERO_CREDENTIALS_PASSPHRASE", "")
+	if _, err := storedToken(""); !errors.Is(err, errNoCredentialPassphrase) {
+		t.Errorf("err = %v, want %v", err, errNoCredentialPassphrase)
+	}
+}
@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/i582/cfmt/cmd/cfmt"
+)
+
+var debug bool
+
+// bodies longer than this are cut in the trace
+const debugBodyLimit = 64 * 1024
+
+const redacted = "[REDACTED]"
+
+// headers and JSON fields whose values never show up in a trace
+var sensitiveName = regexp.MustCompile(`(?i)(authorization|cookie|token|password|passwd|secret|api-?key|auth|credential|private)`)
+
+func init() {
+	rootCmd.PersistentFlags().BoolVarP(&debug, "debug", "v", false, "Print HTTP requests and responses with timings to stderr (env KUBERO_DEBUG)")
+}
+
+func debugEnabled() bool {
+	if debug {
+		return true
+	}
+	enabled, _ := strconv.ParseBool(os.Getenv("KUBERO_DEBUG"))
+	return enabled
+}
+
+// enableDebug prints a trace of every request sent by c to stderr. Secrets in
+// headers and JSON bodies are redacted.
+func enableDebug(c *resty.Client) {
+	c.EnableTrace()
+	c.OnAfterResponse(func(_ *resty.Client, resp *resty.Response) error {
+		printRequestTrace(resp.Request)
+		printResponseTrace(resp)
+		return nil
+	})
+	c.OnError(func(req *resty.Request, err error) {
+		var respErr *resty.ResponseError
+		if errors.As(err, &respErr) && respErr.Response.RawResponse != nil {
+			// already traced by OnAfterResponse
+			return
+		}
+		printRequestTrace(req)
+		cfmt.Fprint(os.Stderr, "{{← failed}}::red ")
+		fmt.Fprintln(os.Stderr, err.Error())
+		printTimings(req.TraceInfo())
+	})
+}
+
+func printRequestTrace(req *resty.Request) {
+	cfmt.Fprint(os.Stderr, "{{→}}::cyan ")
+	fmt.Fprintln(os.Stderr, req.Method, requestURL(req))
+
+	header := req.Header
+	if req.RawRequest != nil {
+		header = req.RawRequest.Header
+	}
+	printHeaders(header)
+
+	if req.Body == nil {
+		return
+	}
+	var body []byte
+	switch b := req.Body.(type) {
+	case []byte:
+		body = b
+	case string:
+		body = []byte(b)
+	default:
+		var err error
+		if body, err = json.Marshal(b); err != nil {
+			body = []byte(fmt.Sprintf("%+v", b))
+		}
+	}
+	printBody(body)
+}
+
+func printResponseTrace(resp *resty.Response) {
+	color := "green"
+	if !resp.IsSuccess() && resp.StatusCode() != http.StatusNotModified {
+		color = "red"
+	}
+	cfmt.Fprint(os.Stderr, "{{← "+strconv.Itoa(resp.StatusCode())+"}}::"+color+" ")
+	fmt.Fprintln(os.Stderr, http.StatusText(resp.StatusCode()), "("+resp.Time().Round(time.Millisecond).String()+")")
+	printTimings(resp.Request.TraceInfo())
+	printHeaders(resp.Header())
+	printBody(resp.Body())
+}
+
+func requestURL(req *resty.Request) string {
+	if req.RawRequest != nil {
+		return req.RawRequest.URL.String()
+	}
+	return req.URL
+}
+
+func printTimings(trace resty.TraceInfo) {
+	round := func(d time.Duration) string {
+		return d.Round(time.Microsecond * 100).String()
+	}
+	fmt.Fprintf(os.Stderr, "  dns %s, connect %s, tls %s, server %s, response %s, total %s, attempt %d, reused connection %t\n",
+		round(trace.DNSLookup), round(trace.TCPConnTime), round(trace.TLSHandshake),
+		round(trace.ServerTime), round(trace.ResponseTime), round(trace.TotalTime),
+		trace.RequestAttempt, trace.IsConnReused)
+}
+
+func printHeaders(header http.Header) {
+	names := make([]string, 0, len(header))
+	for name := range header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		for _, value := range header[name] {
+			fmt.Fprintf(os.Stderr, "  %s: %s\n", name, redactHeader(name, value))
+		}
+	}
+}
+
+func redactHeader(name string, value string) string {
+	if !sensitiveName.MatchString(name) {
+		return value
+	}
+	// keep the scheme, it tells whether the right kind of credential is sent
+	if scheme, _, found := strings.Cut(value, " "); found {
+		return scheme + " " + redacted
+	}
+	return redacted
+}
+
+// printBody prints a body, JSON pretty printed and with the values of
+// sensitive fields redacted.
+func printBody(body []byte) {
+	if len(body) == 0 {
+		return
+	}
+
+	var data interface{}
+	if err := json.Unmarshal(body, &data); err == nil {
+		if pretty, err := json.MarshalIndent(redactJSON(data), "  ", "  "); err == nil {
+			body = pretty
+		}
+	}
+
+	truncated := len(body) > debugBodyLimit
+	if truncated {
+		body = body[:debugBodyLimit]
+	}
+	fmt.Fprintln(os.Stderr, "  "+string(bytes.TrimRight(body, "\n")))
+	if truncated {
+		fmt.Fprintf(os.Stderr, "  ... truncated after %d bytes\n", debugBodyLimit)
+	}
+}
+
+func redactJSON(data interface{}) interface{} {
+	switch v := data.(type) {
+	case map[string]interface{}:
+		// env vars are objects like {"name": "DB_PASSWORD", "value": "..."}
+		if name, ok := v["name"].(string); ok && sensitiveName.MatchString(name) {
+			if _, isString := v["value"].(string); isString {
+				v["value"] = redacted
+			}
+		}
+		for key, value := range v {
+			if _, isString := value.(string); isString && sensitiveName.MatchString(key) {
+				v[key] = redacted
+			} else {
+				v[key] = redactJSON(value)
+			}
+		}
+	case []interface{}:
+		for i, value := range v {
+			v[i] = redactJSON(value)
+		}
+	}
+	return data
+}
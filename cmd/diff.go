@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/pmezard/go-difflib/difflib"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var diffExitCode bool
+
+var diffCmd = &cobra.Command{
+	Use:   "diff -f <file|dir>",
+	Short: "Show the differences between pipeline.yaml and app.<phase>.yaml files and the server",
+	Long: `Show the differences between KuberoPipeline and KuberoApp documents and the
+pipelines and apps on the server as a unified diff, the same way 'apply' would
+change them. Fields managed by the server, like the deploy keys and the
+webhook of a pipeline, are ignored.
+
+With --exit-code the command exits with 8 if there are differences, which lets
+a CI job detect changes made in the UI.`,
+	Example: `  kubero diff -f app.production.yaml
+  kubero diff -f . --exit-code`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		manifests, err := readManifests(manifestFiles)
+		if err != nil {
+			exitWithError(err)
+		}
+
+		drift := false
+		for _, m := range manifests {
+			live, err := m.live(cmd.Context())
+			if err != nil {
+				exitApplyError(m, err)
+			}
+
+			diff, err := manifestDiff(m, live)
+			if err != nil {
+				exitApplyError(m, err)
+			}
+			if diff == "" {
+				continue
+			}
+			drift = true
+			printDiff(diff)
+		}
+
+		if drift && diffExitCode {
+			os.Exit(exitCodeDrift)
+		}
+	},
+}
+
+func init() {
+	diffCmd.Flags().StringArrayVarP(&manifestFiles, "filename", "f", nil, "File or directory with the documents to compare (repeatable)")
+	diffCmd.Flags().BoolVar(&diffExitCode, "exit-code", false, "Exit with 8 if there are differences")
+	diffCmd.MarkFlagRequired("filename")
+	rootCmd.AddCommand(diffCmd)
+}
+
+// manifestDiff returns a unified diff from the object on the server to the
+// document. Objects missing on the server are diffed against nothing.
+func manifestDiff(m *manifest, live interface{}) (string, error) {
+	var from []string
+	if live != nil {
+		data, err := yaml.Marshal(m.document(normalizeSpec(live)))
+		if err != nil {
+			return "", err
+		}
+		from = difflib.SplitLines(string(data))
+	}
+	to, err := yaml.Marshal(m.document(normalizeSpec(m.spec())))
+	if err != nil {
+		return "", err
+	}
+
+	return difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        from,
+		B:        difflib.SplitLines(string(to)),
+		FromFile: "server/" + strings.ReplaceAll(m.String(), " ", "/"),
+		ToFile:   m.file,
+		Context:  3,
+	})
+}
+
+func printDiff(diff string) {
+	for _, line := range strings.SplitAfter(diff, "\n") {
+		if line == "" {
+			continue
+		}
+		text := strings.TrimSuffix(line, "\n")
+		switch {
+		case strings.HasPrefix(text, "---"), strings.HasPrefix(text, "+++"):
+			fmt.Println(text)
+		case strings.HasPrefix(text, "@@"):
+			fmt.Println(colorize(text, "cyan"))
+		case strings.HasPrefix(text, "-"):
+			fmt.Println(colorize(text, "red"))
+		case strings.HasPrefix(text, "+"):
+			fmt.Println(colorize(text, "green"))
+		default:
+			fmt.Println(text)
+		}
+	}
+}
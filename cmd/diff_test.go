@@ -0,0 +1,50 @@
+package cmd
+
+import (
+	"kubero/pkg/kuberoapi"
+	"strings"
+	"testing"
+)
+
+func TestManifestDiff(t *testing.T) {
+	local := func(change func(spec *kuberoapi.PipelineSpec)) *manifest {
+		m := &manifest{file: "pipeline.yaml", pipeline: &kuberoapi.CreatePipeline{}}
+		m.pipeline.Spec.Name = "shop"
+		m.pipeline.Spec.Dockerimage = "ghcr.io/acme/shop"
+		change(&m.pipeline.Spec)
+		return m
+	}
+	live := &kuberoapi.PipelineSpec{}
+	live.Name = "shop"
+	live.Dockerimage = "ghcr.io/acme/shop"
+	live.Git.Keys.Title = "deploy key"
+
+	tests := []struct {
+		name   string
+		m      *manifest
+		change string
+	}{
+		{"same spec", local(func(spec *kuberoapi.PipelineSpec) {}), ""},
+		{"image pull secret of pipeline.yaml", local(func(spec *kuberoapi.PipelineSpec) {
+			spec.ImagePullSecret = "ghcr"
+		}), ""},
+		{"changed image", local(func(spec *kuberoapi.PipelineSpec) {
+			spec.Dockerimage = "ghcr.io/acme/web"
+		}), "+    dockerimage: ghcr.io/acme/web"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			diff, err := manifestDiff(tt.m, live)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tt.change == "" && diff != "" {
+				t.Errorf("unexpected diff\n%s", diff)
+			}
+			if tt.change != "" && !strings.Contains(diff, tt.change) {
+				t.Errorf("diff without %q\n%s", tt.change, diff)
+			}
+		})
+	}
+}
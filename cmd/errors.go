@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"kubero/pkg/kuberoapi"
+	"os"
+
+	"github.com/i582/cfmt/cmd/cfmt"
+)
+
+// Exit codes of the kubero CLI. They are part of the public interface, since
+// scripts rely on them to find out why a command failed.
+const (
+	exitCodeError       = 1 // any other error
+	exitCodeAuth        = 2 // the server rejected the token (401, 403)
+	exitCodeNotFound    = 3 // the pipeline or app does not exist (404)
+	exitCodeValidation  = 4 // the server rejected the request as invalid (400, 409, 422)
+	exitCodeServer      = 5 // the server failed to process the request (5xx)
+	exitCodeNetwork     = 6 // the server could not be reached
+	exitCodeUnsupported = 7 // the server is too old for the command
+	exitCodeDrift       = 8 // diff --exit-code found differences
+)
+
+// exitWithError prints a readable message for err to stderr and exits with
+// the exit code matching its class.
+func exitWithError(err error) {
+	title, code := classifyError(err)
+
+	cfmt.Fprintln(os.Stderr, "{{✗ "+title+"}}::red")
+	fmt.Fprintln(os.Stderr, "  "+errorDetail(err))
+
+	os.Exit(code)
+}
+
+func classifyError(err error) (string, int) {
+	switch {
+	case isUnsupported(err):
+		return "The Kubero server does not support this command. Update Kubero to use it", exitCodeUnsupported
+	case kuberoapi.IsUnauthorized(err):
+		return "Authentication failed. Check your token or run 'kubero login'", exitCodeAuth
+	case kuberoapi.IsNotFound(err):
+		return "Not found", exitCodeNotFound
+	case kuberoapi.IsValidation(err):
+		return "The request was rejected by the server", exitCodeValidation
+	case kuberoapi.IsServerError(err):
+		return "The server failed to process the request", exitCodeServer
+	case kuberoapi.IsNetworkError(err):
+		return "Could not reach the Kubero server", exitCodeNetwork
+	default:
+		return "Error", exitCodeError
+	}
+}
+
+// errorDetail returns the server's message for API errors and the full
+// error text otherwise.
+func errorDetail(err error) string {
+	var apiErr *kuberoapi.APIError
+	if errors.As(err, &apiErr) {
+		if apiErr.Message != "" {
+			return fmt.Sprintf("%s (%d %s %s)", apiErr.Message, apiErr.StatusCode, apiErr.Method, apiErr.URL)
+		}
+		return fmt.Sprintf("%d %s %s", apiErr.StatusCode, apiErr.Method, apiErr.URL)
+	}
+	return err.Error()
+}
@@ -1,18 +1,140 @@
 package cmd
 
 import (
-	"github.com/go-resty/resty/v2"
+	"fmt"
+	"kubero/pkg/kuberoapi"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/i582/cfmt/cmd/cfmt"
 	"github.com/spf13/viper"
 )
 
-var client *resty.Request
+var client *kuberoapi.Client
+
+var requestTimeout time.Duration
+var requestRetries int
+var requestRetryWait time.Duration
 
+func init() {
+	rootCmd.PersistentFlags().DurationVar(&requestTimeout, "timeout", 30*time.Second, "Timeout of a single API request, 0 to wait forever (env KUBERO_TIMEOUT)")
+	rootCmd.PersistentFlags().IntVar(&requestRetries, "retries", kuberoapi.DefaultRetryPolicy.Retries, "Number of retries of failed GET and DELETE requests (env KUBERO_RETRIES)")
+	rootCmd.PersistentFlags().DurationVar(&requestRetryWait, "retry-wait", kuberoapi.DefaultRetryPolicy.WaitTime, "Wait before the first retry, doubled for each further one (env KUBERO_RETRY_WAIT)")
+}
+
+// InitClient creates the API client for the active profile. It runs after the
+// flags are parsed, since they may select the profile.
 func InitClient() {
-	client = resty.New().SetBaseURL(viper.GetString("api.url")).R().
-		EnableTrace().
-		SetAuthScheme("Bearer").
-		SetAuthToken(viper.GetString("api.token")).
-		SetHeader("Accept", "application/json").
-		SetHeader("Content-Type", "application/json").
-		SetHeader("User-Agent", "kubero-cli/0.0.1")
+	checkActiveProfile()
+	client = newAPIClient(configString("api.url"), "")
+	client.SetCache(&diskCache{dir: cacheDir(), refresh: refreshCache}, cacheTTL())
+	// credential helpers are only run by commands which call the API
+	client.SetTokenSource(func() (string, error) {
+		token, _, err := resolveToken()
+		return token, err
+	})
+}
+
+// newAPIClient creates a client for a Kubero server with the TLS, proxy,
+// timeout, retry and debug settings of the active profile.
+func newAPIClient(url string, token string) *kuberoapi.Client {
+	c := kuberoapi.NewClient(url, token).SetUserAgent(userAgent())
+	c.Resty().EnableTrace()
+	if err := applyTransportConfig(c.Resty(), true); err != nil {
+		exitWithError(err)
+	}
+	if debugEnabled() {
+		enableDebug(c.Resty())
+	}
+
+	timeout, err := time.ParseDuration(setting("timeout", "KUBERO_TIMEOUT", "api.timeout"))
+	if err != nil {
+		exitWithError(fmt.Errorf("invalid timeout: %w", err))
+	}
+	retries, err := strconv.Atoi(setting("retries", "KUBERO_RETRIES", "api.retries"))
+	if err != nil {
+		exitWithError(fmt.Errorf("invalid number of retries: %w", err))
+	}
+	retryWait, err := time.ParseDuration(setting("retry-wait", "KUBERO_RETRY_WAIT", "api.retryWait"))
+	if err != nil {
+		exitWithError(fmt.Errorf("invalid retry wait: %w", err))
+	}
+
+	retryPolicy := kuberoapi.DefaultRetryPolicy
+	retryPolicy.Retries = retries
+	retryPolicy.WaitTime = retryWait
+	retryPolicy.OnRetry = func(attempt int, wait time.Duration, err error) {
+		cfmt.Fprint(os.Stderr, "{{⟳ Retry "+strconv.Itoa(attempt)+"/"+strconv.Itoa(retries)+" in "+wait.Round(time.Millisecond).String()+"}}::yellow ")
+		fmt.Fprintln(os.Stderr, errorDetail(err))
+	}
+	return c.SetTimeout(timeout).SetRetryPolicy(retryPolicy)
+}
+
+// setting returns the value of a global flag if it is given on the command
+// line, else of the environment variable, else of the config key of the
+// active profile, else the flag's default.
+func setting(flag string, env string, key string) string {
+	f := rootCmd.PersistentFlags().Lookup(flag)
+	if f.Changed {
+		return f.Value.String()
+	}
+	if value := os.Getenv(env); value != "" {
+		return value
+	}
+	if value := configString(key); value != "" {
+		return value
+	}
+	return f.DefValue
+}
+
+// tokenKey returns the config key holding the token of the active profile.
+// Unlike other settings the token is never inherited from the top level, so
+// it can't be sent to the server of another profile.
+func tokenKey() string {
+	if profile := activeProfile(); profile != "" {
+		return "profiles." + profile + ".api.token"
+	}
+	return "api.token"
+}
+
+// resolveToken returns the token of the active profile and a description of
+// where it came from. The api.tokenCommand takes precedence over the
+// credential store, which takes precedence over a plaintext api.token.
+func resolveToken() (string, string, error) {
+	profile := activeProfile()
+
+	if command := trustedConfigString("api.tokenCommand"); command != "" {
+		token, err := runTokenCommand(command, profile)
+		return token, "api.tokenCommand '" + command + "'", err
+	}
+
+	token, err := storedToken(profile)
+	if err != nil {
+		return "", "", err
+	}
+	if token != "" {
+		return token, credentialName(profile) + " in the credential store " + credentialStoreFile(), nil
+	}
+
+	key := tokenKey()
+	token = viper.GetString(key)
+	if token == "" {
+		return "", "", nil
+	}
+
+	source := systemConfigFile
+	if readConfigFile(personalConfigFile()).IsSet(key) {
+		source = personalConfigFile()
+	} else if projectConfig := viper.ConfigFileUsed(); projectConfig != "" && readConfigFile(projectConfig).IsSet(key) {
+		source = projectConfig
+	}
+	return token, key + " in " + source, nil
+}
+
+func readConfigFile(fileName string) *viper.Viper {
+	config := viper.New()
+	config.SetConfigFile(fileName)
+	config.ReadInConfig()
+	return config
 }
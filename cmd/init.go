@@ -3,45 +3,41 @@ package cmd
 import (
 	"fmt"
 
+	"github.com/i582/cfmt/cmd/cfmt"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 )
 
-type Config struct {
-	Api struct {
-		Url   string `json:"url" yaml:"url"`
-		Token string `json:"token" yaml:"token"`
-	} `json:"api" yaml:"api"`
-}
-
 // initCmd represents the init command
 var initCmd = &cobra.Command{
 	Use:   "init",
 	Short: "Configure your kubero-cli",
-	/*
-			Long: `A longer description that spans multiple lines and likely contains examples
-		and usage of using your command. For example:
-
-		Cobra is a CLI library for Go that empowers applications.
-		This application is a tool to generate the needed files
-		to quickly create a Cobra application.`,
-	*/
+	Long: `Configure your kubero-cli
+
+Saves the server URL of the active profile to $HOME/.kubero/kubero.yaml and
+the token like 'kubero login' does, by default to the encrypted credential
+store. The kubero.yaml of the project is not changed.`,
 	Run: func(cmd *cobra.Command, args []string) {
 
 		fmt.Println("Initializing kubero-cli")
-		url := promptLine("Kubero Host adress", viper.GetString("api.url"), viper.GetString("api.url"))
-		viper.Set("api.url", url)
-
-		token := promptLine("Kubero Token", viper.GetString("api.token"), viper.GetString("api.token"))
-		viper.Set("api.token", token)
-
-		var config Config
-		if err := viper.Unmarshal(&config); err != nil {
-			fmt.Println(err)
-			return
+		url := promptLine("Kubero Host adress", configString("api.url"), configString("api.url"))
+
+		token := promptSecret("Kubero Token (leave empty to keep the current one)")
+		check := token
+		if check == "" {
+			current, _, err := resolveToken()
+			if err != nil {
+				exitWithError(err)
+			}
+			check = current
+		}
+		if _, err := validateToken(cmd.Context(), newAPIClient(url, check)); err != nil {
+			exitWithError(err)
 		}
 
-		viper.WriteConfig()
+		if err := saveLogin(url, token); err != nil {
+			exitWithError(err)
+		}
+		cfmt.Println("{{✓ Kubero CLI config written to " + personalConfigFile() + "}}::green")
 	},
 }
 
@@ -12,11 +12,8 @@ import (
 
 	"encoding/json"
 
-	"github.com/go-resty/resty/v2"
 	"github.com/i582/cfmt/cmd/cfmt"
-	"github.com/leaanthony/spinner"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 	"gopkg.in/yaml.v3"
 	"k8s.io/client-go/tools/clientcmd"
 )
@@ -176,10 +173,9 @@ func installKubernetes() {
 
 func tellAChucknorrisJoke() {
 
-	jokesapi := resty.New().
+	jokesapi := newInstallerClient().
 		SetHeader("Accept", "application/json").
 		SetHeader("Content-Type", "application/json").
-		SetHeader("User-Agent", "kubero-cli/0.0.1").
 		SetBaseURL("https://api.chucknorris.io/jokes/random")
 
 	joke, _ := jokesapi.R().Get("?category=dev")
@@ -263,7 +259,7 @@ func installOLM() {
 	olmRelease := promptLine("Install OLM from which release?", "[0.20.0,0.21.0,0.22.0,0.23.1]", "0.23.1")
 	olmURL := "https://github.com/operator-framework/operator-lifecycle-manager/releases/download/v" + olmRelease
 
-	olmSpinner := spinner.New("Install OLM")
+	olmSpinner := newSpinner("Install OLM")
 
 	olmCRDInstalled, _ := exec.Command("kubectl", "get", "crd", "subscriptions.operators.coreos.com").Output()
 	if len(olmCRDInstalled) > 0 {
@@ -290,7 +286,7 @@ func installOLM() {
 	}
 	olmSpinner.Success("OLM installed sucessfully")
 
-	olmWaitSpinner := spinner.New("Wait for OLM to be ready")
+	olmWaitSpinner := newSpinner("Wait for OLM to be ready")
 	olmWaitSpinner.Start("run command : kubectl wait --for=condition=available deployment/olm-operator -n " + namespace + " --timeout=180s")
 	_, olmWaitErr := exec.Command("kubectl", "wait", "--for=condition=available", "deployment/olm-operator", "-n", namespace, "--timeout=180s").Output()
 	if olmWaitErr != nil {
@@ -299,7 +295,7 @@ func installOLM() {
 	}
 	olmWaitSpinner.Success("OLM is ready")
 
-	olmWaitCatalogSpinner := spinner.New("Wait for OLM Catalog to be ready")
+	olmWaitCatalogSpinner := newSpinner("Wait for OLM Catalog to be ready")
 	olmWaitCatalogSpinner.Start("run command : kubectl wait --for=condition=available deployment/catalog-operator -n " + namespace + " --timeout=180s")
 	_, olmWaitCatalogErr := exec.Command("kubectl", "wait", "--for=condition=available", "deployment/catalog-operator", "-n", namespace, "--timeout=180s").Output()
 	if olmWaitCatalogErr != nil {
@@ -364,7 +360,7 @@ func installIngress() {
 		}
 
 		ingressProvider := promptLine("Provider", "[kind,aws,baremetal,cloud(Azure,Google,Oracle,Linode),do(digital ocean),exoscale,scw(scaleway)]", prefill)
-		ingressSpinner := spinner.New("Install Ingress")
+		ingressSpinner := newSpinner("Install Ingress")
 		URL := "https://raw.githubusercontent.com/kubernetes/ingress-nginx/controller-" + ingressControllerVersion + "/deploy/static/provider/" + ingressProvider + "/deploy.yaml"
 		ingressSpinner.Start("run command : kubectl apply -f " + URL)
 		_, ingressErr := exec.Command("kubectl", "apply", "-f", URL).Output()
@@ -396,7 +392,7 @@ func installKuberoOperator() {
 
 func installKuberoOLMOperator() {
 
-	kuberoSpinner := spinner.New("Install Kubero Operator")
+	kuberoSpinner := newSpinner("Install Kubero Operator")
 	kuberoSpinner.Start("run command : kubectl apply -f https://operatorhub.io/install/kubero-operator.yaml")
 	_, kuberoErr := exec.Command("kubectl", "apply", "-f", "https://operatorhub.io/install/kubero-operator.yaml").Output()
 	if kuberoErr != nil {
@@ -419,7 +415,7 @@ func installKuberoOLMOperator() {
 
 func installKuberoOperatorSlim() {
 
-	kuberoSpinner := spinner.New("Install Kubero Operator")
+	kuberoSpinner := newSpinner("Install Kubero Operator")
 	kuberoSpinner.Start("run command : kubectl apply -f https://raw.githubusercontent.com/kubero-dev/kubero-operator/main/deploy/operator.yaml")
 	_, kuberoErr := exec.Command("kubectl", "apply", "-f", "https://raw.githubusercontent.com/kubero-dev/kubero-operator/main/deploy/operator.yaml").Output()
 	if kuberoErr != nil {
@@ -555,7 +551,7 @@ func installKuberoUi() {
 	if len(kuberoUIInstalled) > 0 {
 		cfmt.Println("{{✓ Kubero UI allready installed}}::lightGreen")
 	} else {
-		installer := resty.New()
+		installer := newInstallerClient()
 
 		installer.SetBaseURL("https://raw.githubusercontent.com")
 		kf, _ := installer.R().Get("kubero-dev/kubero-operator/main/config/samples/application_v1alpha1_kubero.yaml")
@@ -600,7 +596,7 @@ func installKuberoUi() {
 			cfmt.Println("{{✓ Kubero UI installed}}::lightGreen")
 		}
 
-		kuberoUISpinner := spinner.New("Wait for Kubero UI to be created")
+		kuberoUISpinner := newSpinner("Wait for Kubero UI to be created")
 		kuberoUISpinner.Start("Wait for Kubero UI to be created")
 
 		var kuberoWait []byte
@@ -645,7 +641,7 @@ func installCertManagerSlim() {
 		return
 	}
 
-	certManagerSpinner := spinner.New("Install Cert Manager")
+	certManagerSpinner := newSpinner("Install Cert Manager")
 	certManagerSpinner.Start("run command : kubectl create -f https://github.com/cert-manager/cert-manager/releases/download/v1.11.0/cert-manager.yaml")
 	_, certManagerErr := exec.Command("kubectl", "create", "-f", "https://github.com/cert-manager/cert-manager/releases/download/v1.11.0/cert-manager.yaml").Output()
 	if certManagerErr != nil {
@@ -660,7 +656,7 @@ func installCertManagerSlim() {
 
 func installCertManagerClusterissuer() {
 
-	installer := resty.New()
+	installer := newInstallerClient()
 
 	installer.SetBaseURL("https://raw.githubusercontent.com")
 	kf, _ := installer.R().Get("kubero-dev/kubero-cli/main/templates/certmanagerClusterIssuer.prod.yaml")
@@ -699,7 +695,7 @@ func installOLMCertManager() {
 		return
 	}
 
-	certManagerSpinner := spinner.New("Install Cert Manager")
+	certManagerSpinner := newSpinner("Install Cert Manager")
 	certManagerSpinner.Start("run command : kubectl create -f https://operatorhub.io/install/cert-manager.yaml")
 	_, certManagerErr := exec.Command("kubectl", "create", "-f", "https://operatorhub.io/install/cert-manager.yaml").Output()
 	if certManagerErr != nil {
@@ -709,7 +705,7 @@ func installOLMCertManager() {
 	certManagerSpinner.Success("Cert Manager installed")
 
 	time.Sleep(2 * time.Second)
-	certManagerSpinner = spinner.New("Wait for Cert Manager to be ready")
+	certManagerSpinner = newSpinner("Wait for Cert Manager to be ready")
 	certManagerSpinner.Start("run command : kubectl wait --for=condition=available deployment/cert-manager-webhook -n cert-manager --timeout=180s -n operators")
 	_, certManagerWaitErr := exec.Command("kubectl", "wait", "--for=condition=available", "deployment/cert-manager-webhook", "-n", "cert-manager", "--timeout=180s", "-n", "operators").Output()
 	if certManagerWaitErr != nil {
@@ -728,19 +724,13 @@ func writeCLIconfig() {
 
 	//TODO consider using SSL here.
 	url := promptLine("Kubero Host adress", "", "http://"+arg_domain+":"+arg_port)
-	viper.Set("api.url", url)
 
 	token := promptLine("Kubero Token", "", arg_apiToken)
-	viper.Set("api.token", token)
-
-	var config Config
-	if err := viper.Unmarshal(&config); err != nil {
-		fmt.Println(err)
+	if err := saveLogin(url, token); err != nil {
+		cfmt.Println("{{✗ Failed to write the Kubero CLI config: " + err.Error() + "}}::red")
 		return
 	}
-	fmt.Printf("%+v\n", config)
-
-	viper.WriteConfig()
+	cfmt.Println("{{✓ Kubero CLI config written to " + personalConfigFile() + "}}::lightGreen")
 }
 
 func printDNSinfo() {
@@ -767,7 +757,7 @@ func printDNSinfo() {
 }
 
 func finalMessage() {
-	cfmt.Println(`
+	cfmt.Print(`
 
     ,--. ,--.        ,--.
     |  .'   /,--.,--.|  |-.  ,---. ,--.--. ,---.
@@ -787,7 +777,7 @@ func finalMessage() {
     Pass: ` + arg_adminPassword + `}}::lightBlue
 	`)
 	} else {
-		cfmt.Println("\n\n    {{Done - you can now login to your Kubero UI}}::lightGreen\n\n")
+		cfmt.Print("\n\n    {{Done - you can now login to your Kubero UI}}::lightGreen\n\n\n")
 	}
 }
 
@@ -9,9 +9,7 @@ import (
 	"strconv"
 	"time"
 
-	"github.com/go-resty/resty/v2"
 	"github.com/i582/cfmt/cmd/cfmt"
-	"github.com/leaanthony/spinner"
 )
 
 func installDigitalOcean() {
@@ -26,12 +24,11 @@ func installDigitalOcean() {
 		log.Fatal("missing DIGITALOCEAN_ACCESS_TOKEN")
 	}
 
-	doApi := resty.New().
+	doApi := newInstallerClient().
 		SetAuthScheme("Bearer").
 		SetAuthToken(token).
 		SetHeader("Accept", "application/json").
 		SetHeader("Content-Type", "application/json").
-		SetHeader("User-Agent", "kubero-cli/0.0.1"). //TODO dynamic version
 		SetBaseURL("https://api.digitalocean.com")
 
 	var doConfig DigitalOceanKubernetesConfig
@@ -69,7 +66,7 @@ func installDigitalOcean() {
 	var doCluster DigitalOcean
 	json.Unmarshal(kf.Body(), &doCluster)
 
-	doSpinner := spinner.New("Starting a kubernetes cluster on digital ocean")
+	doSpinner := newSpinner("Starting a kubernetes cluster on digital ocean")
 	doSpinner.Start("Waiting for digital ocean cluster to be ready. This may take a few minutes. Time enough to get a coffee ☕")
 	clusterID := doCluster.KubernetesCluster.ID
 
@@ -6,8 +6,6 @@ import (
 	"math/rand"
 	"os/exec"
 	"strconv"
-
-	"github.com/leaanthony/spinner"
 )
 
 func installGKE() {
@@ -25,7 +23,7 @@ func installGKE() {
 	gcloudRegion := promptLine("Region", "[https://cloud.google.com/compute/docs/regions-zones]", "us-central1-c")
 	gcloudClusterVersion := promptLine("Cluster Version", "[https://cloud.google.com/kubernetes-engine/docs/release-notes-regular]", "1.23.8-gke.1900")
 
-	spinner := spinner.New("Spin up a GKE cluster")
+	spinner := newSpinner("Spin up a GKE cluster")
 	spinner.Start("run command : gcloud container clusters create " + gcloudName + " --region=" + gcloudRegion + " --cluster-version=" + gcloudClusterVersion)
 	_, err := exec.Command("gcloud", "container", "clusters", "create", gcloudName,
 		"--region="+gcloudRegion,
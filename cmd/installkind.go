@@ -8,8 +8,6 @@ import (
 	"os/exec"
 	"strconv"
 
-	"github.com/go-resty/resty/v2"
-	"github.com/leaanthony/spinner"
 	"gopkg.in/yaml.v3"
 )
 
@@ -19,7 +17,7 @@ func installKind() {
 		log.Fatal("kind binary is not installed")
 	}
 
-	installer := resty.New()
+	installer := newInstallerClient()
 
 	installer.SetBaseURL("https://raw.githubusercontent.com")
 	kf, _ := installer.R().Get("/kubero-dev/kubero/main/kind.yaml")
@@ -51,7 +49,7 @@ func installKind() {
 		return
 	}
 
-	kindSpinner := spinner.New("Spin up a local Kind cluster")
+	kindSpinner := newSpinner("Spin up a local Kind cluster")
 	kindSpinner.Start("run command : kind create cluster --config kind.yaml")
 	out, err := exec.Command("kind", "create", "cluster", "--config", "kind.yaml").Output()
 	if err != nil {
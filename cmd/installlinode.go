@@ -10,9 +10,7 @@ import (
 	"strconv"
 	"time"
 
-	"github.com/go-resty/resty/v2"
 	"github.com/i582/cfmt/cmd/cfmt"
-	"github.com/leaanthony/spinner"
 )
 
 func installLinode() {
@@ -29,12 +27,11 @@ func installLinode() {
 		log.Fatal("missing LINODE_ACCESS_TOKEN")
 	}
 
-	api := resty.New().
+	api := newInstallerClient().
 		SetAuthScheme("Bearer").
 		SetAuthToken(token).
 		SetHeader("Accept", "application/json").
 		SetHeader("Content-Type", "application/json").
-		SetHeader("User-Agent", "kubero-cli/0.0.1").
 		SetBaseURL("https://api.linode.com/v4/lke/clusters")
 
 	var clusterConfig LinodeCreateClusterRequest
@@ -53,7 +50,7 @@ func installLinode() {
 		},
 	}
 
-	spinner := spinner.New("Spin up a Linode Kubernetes Cluster")
+	spinner := newSpinner("Spin up a Linode Kubernetes Cluster")
 
 	spinner.Start("Create Linode Kubernetes Cluster")
 	clusterResponse, _ := api.R().SetBody(clusterConfig).Post("")
@@ -9,9 +9,7 @@ import (
 	"strconv"
 	"time"
 
-	"github.com/go-resty/resty/v2"
 	"github.com/i582/cfmt/cmd/cfmt"
-	"github.com/leaanthony/spinner"
 )
 
 func installScaleway() {
@@ -43,11 +41,10 @@ func installScaleway() {
 		log.Fatal("missing SCALEWAY_ACCESS_TOKEN")
 	}
 
-	api := resty.New().
+	api := newInstallerClient().
 		SetHeader("X-Auth-Token", token).
 		SetHeader("Accept", "application/json").
 		SetHeader("Content-Type", "application/json").
-		SetHeader("User-Agent", "kubero-cli/0.0.1").
 		SetBaseURL("https://api.scaleway.com/k8s/v1/regions")
 
 	cluster.Name = promptLine("Kubernetes Cluster Name", "", "kubero-"+strconv.Itoa(rand.Intn(1000)))
@@ -93,8 +90,8 @@ func installScaleway() {
 		log.Fatal(string(newCluster.Body()))
 	}
 
-	spinner := spinner.New()
-	spinner.Start("Waiting for cluster to be ready")
+	spinner := newSpinner("Waiting for cluster to be ready")
+	spinner.Start()
 	for {
 		clusterStatus, _ := api.R().Get(region + "/clusters/" + clusterResponse.ID)
 		var clusterStatusResponse ScalewayCreateResponse
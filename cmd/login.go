@@ -0,0 +1,208 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"kubero/pkg/kuberoapi"
+	"os"
+
+	"github.com/i582/cfmt/cmd/cfmt"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// loginCmd represents the login command
+var loginCmd = &cobra.Command{
+	Use:   "login",
+	Short: "Log in to a Kubero server",
+	Long: `Log in to a Kubero server with a username and password or with an API token.
+
+The credentials are checked against the server before they are saved to the
+active profile. By default the token is saved to the credential store in
+$HOME/.kubero/credentials, encrypted with KUBERO_CREDENTIALS_PASSPHRASE. Set
+api.credentialStore to "keyfile" to use a key file next to the store instead,
+which only obfuscates the token, or to "config" to save it in plaintext to
+$HOME/.kubero/kubero.yaml.`,
+	// logging in may create the selected profile
+	PersistentPreRun: preRunWithoutClient,
+	Run: func(cmd *cobra.Command, args []string) {
+
+		url := loginURL
+		if url == "" {
+			url = promptLine("Kubero Host adress", "", configString("api.url"))
+		}
+		c := newAPIClient(url, "")
+
+		token := ""
+		if !loginWithToken && loginUsername == "" {
+			method := promptLine("Login with", "[token,password]", "token")
+			loginWithToken = method != "password"
+		}
+		if loginWithToken {
+			token = promptSecret("Kubero Token")
+		} else {
+			if loginUsername == "" {
+				loginUsername = promptLine("Username", "", "admin")
+			}
+			password := promptSecret("Password")
+
+			var err error
+			token, err = c.Login(cmd.Context(), loginUsername, password)
+			if err != nil {
+				exitWithError(err)
+			}
+			if token == "" {
+				exitWithError(fmt.Errorf("the server did not return an API token for %s", loginUsername))
+			}
+		}
+
+		c.SetToken(token)
+		user, err := validateToken(cmd.Context(), c)
+		if err != nil {
+			exitWithError(err)
+		}
+
+		if err := saveLogin(url, token); err != nil {
+			exitWithError(err)
+		}
+		if trustedConfigString("api.tokenCommand") != "" {
+			cfmt.Println("{{⚠ api.tokenCommand is configured and takes precedence over the saved token}}::yellow")
+		}
+
+		if user.Username != "" {
+			cfmt.Println("{{Logged in to " + url + " as " + user.Username + "}}::green")
+		} else {
+			cfmt.Println("{{Logged in to " + url + "}}::green")
+		}
+	},
+}
+
+// logoutCmd represents the logout command
+var logoutCmd = &cobra.Command{
+	Use:              "logout",
+	Short:            "Remove the credentials of the active profile",
+	PersistentPreRun: preRunWithoutClient,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := eraseToken(activeProfile()); err != nil {
+			exitWithError(err)
+		}
+		personal := loadPersonalConfig()
+		api := personalAPIConfig(personal, activeProfile())
+		delete(api, "token")
+		if err := writePersonalConfig(personal); err != nil {
+			exitWithError(err)
+		}
+		cfmt.Println("{{Logged out}}::green")
+
+		// the token may also be set in the kubero.yaml of the project
+		if projectConfig := viper.ConfigFileUsed(); projectConfig != "" && readConfigFile(projectConfig).IsSet(tokenKey()) {
+			cfmt.Println("{{⚠ A token is still configured in " + projectConfig + "}}::yellow")
+		}
+	},
+}
+
+// whoamiCmd represents the whoami command
+var whoamiCmd = &cobra.Command{
+	Use:   "whoami",
+	Short: "Show the active server, user and token",
+	Run: func(cmd *cobra.Command, args []string) {
+		var info whoamiInfo
+		info.Profile = activeProfile()
+		info.Server = client.BaseURL()
+
+		token, source, err := resolveToken()
+		if err != nil {
+			exitWithError(err)
+		}
+		info.TokenSource = source
+		if token == "" {
+			exitWithError(fmt.Errorf("not logged in, run 'kubero login'"))
+		}
+
+		user, err := validateToken(cmd.Context(), client)
+		if err != nil {
+			exitWithError(err)
+		}
+		info.User = user.Username
+
+		printWhoami(info)
+	},
+}
+
+var loginURL string
+var loginUsername string
+var loginWithToken bool
+
+func init() {
+	loginCmd.Flags().StringVar(&loginURL, "url", "", "URL of the Kubero UI")
+	loginCmd.Flags().StringVarP(&loginUsername, "username", "u", "", "Log in with this user's password")
+	loginCmd.Flags().BoolVarP(&loginWithToken, "token", "t", false, "Log in with an API token")
+	rootCmd.AddCommand(loginCmd)
+	rootCmd.AddCommand(logoutCmd)
+	rootCmd.AddCommand(whoamiCmd)
+}
+
+// saveToken saves the token of a profile to the credential store, or to its
+// api section if api.credentialStore is "config".
+func saveToken(api map[string]interface{}, profile string, token string) error {
+	if configString("api.credentialStore") == "config" {
+		api["token"] = token
+		return nil
+	}
+	delete(api, "token")
+	return storeToken(profile, token)
+}
+
+// saveLogin saves the URL of the active profile to the personal config and
+// its token with saveToken. An empty token keeps the saved one.
+func saveLogin(url string, token string) error {
+	personal := loadPersonalConfig()
+	api := personalAPIConfig(personal, activeProfile())
+	api["url"] = url
+	if token != "" {
+		if err := saveToken(api, activeProfile(), token); err != nil {
+			return err
+		}
+	}
+	return writePersonalConfig(personal)
+}
+
+// validateToken checks the token of c against the server. Servers without
+// the whoami endpoint are checked by listing the pipelines instead.
+func validateToken(ctx context.Context, c *kuberoapi.Client) (*kuberoapi.User, error) {
+	user, err := c.WhoAmI(ctx)
+	if kuberoapi.IsNotFound(err) || kuberoapi.IsUnsupported(err) {
+		_, err = c.ListPipelines(ctx)
+		user = &kuberoapi.User{}
+	}
+	return user, err
+}
+
+type whoamiInfo struct {
+	Profile     string `json:"profile"`
+	Server      string `json:"server"`
+	User        string `json:"user"`
+	TokenSource string `json:"tokenSource"`
+}
+
+func printWhoami(info whoamiInfo) {
+	profile := info.Profile
+	if profile == "" {
+		profile = "(none)"
+	}
+	user := info.User
+	if user == "" {
+		user = "(unknown)"
+	}
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetBorder(false)
+	table.SetAutoWrapText(false)
+	table.Append([]string{"Profile", profile})
+	table.Append([]string{"Server", info.Server})
+	table.Append([]string{"User", user})
+	table.Append([]string{"Token", info.TokenSource})
+
+	printCLI(table, info)
+}
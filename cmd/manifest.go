@@ -0,0 +1,291 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"kubero/pkg/kuberoapi"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	kindPipeline = "KuberoPipeline"
+	kindApp      = "KuberoApp"
+)
+
+// manifest is a KuberoPipeline or KuberoApp document in the format written
+// by writePipelineYaml and writeAppYaml.
+type manifest struct {
+	file     string
+	pipeline *kuberoapi.CreatePipeline
+	app      *kuberoapi.CreateApp
+}
+
+func (m *manifest) String() string {
+	if m.pipeline != nil {
+		return "pipeline " + m.pipeline.Spec.Name
+	}
+	return "app " + m.app.Spec.Pipeline + "/" + m.app.Spec.Phase + "/" + m.app.Spec.Name
+}
+
+// spec returns the spec sent to the server.
+func (m *manifest) spec() interface{} {
+	if m.pipeline != nil {
+		return &m.pipeline.Spec
+	}
+	return &m.app.Spec
+}
+
+// document returns the manifest with spec replaced, in the format of the
+// file.
+func (m *manifest) document(spec interface{}) interface{} {
+	if m.pipeline != nil {
+		doc := *m.pipeline
+		doc.Spec = *spec.(*kuberoapi.PipelineSpec)
+		return &doc
+	}
+	doc := *m.app
+	doc.Spec = *spec.(*kuberoapi.AppSpec)
+	return &doc
+}
+
+// live returns the spec stored on the server, or nil if the object does not
+// exist yet.
+func (m *manifest) live(ctx context.Context) (interface{}, error) {
+	var live interface{}
+	var err error
+	if m.pipeline != nil {
+		live, err = client.GetPipelineSpec(ctx, m.pipeline.Spec.Name)
+	} else {
+		var a *kuberoapi.CreateApp
+		a, err = client.GetApp(ctx, m.app.Spec.Pipeline, m.app.Spec.Phase, m.app.Spec.Name)
+		if err == nil {
+			live = &a.Spec
+		}
+	}
+	if kuberoapi.IsNotFound(err) {
+		return nil, nil
+	}
+	return live, err
+}
+
+// readManifests reads the documents of the given files and of the *.yaml and
+// *.yml files in the given directories. Pipelines are returned before apps,
+// since apps can only be created in existing pipelines.
+func readManifests(paths []string) ([]*manifest, error) {
+	var manifests []*manifest
+	for _, path := range paths {
+		info, err := os.Stat(path)
+		if err != nil {
+			return nil, err
+		}
+		if !info.IsDir() {
+			m, err := readManifestFile(path, true)
+			if err != nil {
+				return nil, err
+			}
+			manifests = append(manifests, m...)
+			continue
+		}
+
+		entries, err := os.ReadDir(path)
+		if err != nil {
+			return nil, err
+		}
+		for _, entry := range entries {
+			ext := filepath.Ext(entry.Name())
+			if entry.IsDir() || (ext != ".yaml" && ext != ".yml") {
+				continue
+			}
+			// other files, e.g. a kubero.yaml, are skipped in directories
+			m, err := readManifestFile(filepath.Join(path, entry.Name()), false)
+			if err != nil {
+				return nil, err
+			}
+			manifests = append(manifests, m...)
+		}
+	}
+
+	if len(manifests) == 0 {
+		return nil, fmt.Errorf("no %s or %s documents found in %s", kindPipeline, kindApp, strings.Join(paths, ", "))
+	}
+	sort.SliceStable(manifests, func(i, j int) bool {
+		return manifests[i].pipeline != nil && manifests[j].pipeline == nil
+	})
+	return manifests, nil
+}
+
+// readManifestFile reads all documents of a file. Documents of other kinds
+// are an error if strict is set and skipped otherwise.
+func readManifestFile(file string, strict bool) ([]*manifest, error) {
+	data, err := os.ReadFile(file)
+	if err != nil {
+		return nil, err
+	}
+
+	var manifests []*manifest
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var node yaml.Node
+		err := decoder.Decode(&node)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+
+		var header struct {
+			Kind string `yaml:"kind"`
+		}
+		if node.Decode(&header) != nil {
+			header.Kind = ""
+		}
+
+		m := &manifest{file: file}
+		switch header.Kind {
+		case kindPipeline:
+			m.pipeline = &kuberoapi.CreatePipeline{}
+			err = node.Decode(m.pipeline)
+			if err == nil && m.pipeline.Spec.Name == "" {
+				err = errors.New("spec.name is missing")
+			}
+		case kindApp:
+			m.app = &kuberoapi.CreateApp{}
+			err = node.Decode(m.app)
+			if err == nil && (m.app.Spec.Pipeline == "" || m.app.Spec.Phase == "" || m.app.Spec.Name == "") {
+				err = errors.New("spec.pipeline, spec.phase and spec.name are required")
+			}
+		default:
+			if strict {
+				return nil, fmt.Errorf("%s: unknown kind %q, expected %s or %s", file, header.Kind, kindPipeline, kindApp)
+			}
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%s: %s: %w", file, header.Kind, err)
+		}
+		manifests = append(manifests, m)
+	}
+	return manifests, nil
+}
+
+// normalizeSpec returns a copy of spec without the fields managed by the
+// server, like the deploy keys and the webhook of a pipeline, and without the
+// image pull secret only pipeline.yaml knows. Fields the server adds to its
+// objects, like the status, are not part of the specs.
+func normalizeSpec(spec interface{}) interface{} {
+	switch s := spec.(type) {
+	case *kuberoapi.PipelineSpec:
+		var zero kuberoapi.PipelineSpec
+		normalized := *s
+		normalized.Git.Keys = zero.Git.Keys
+		normalized.Git.Webhook = zero.Git.Webhook
+		normalized.ImagePullSecret = ""
+		return &normalized
+	}
+	return spec
+}
+
+// specChange is a field that differs between the server and a document.
+type specChange struct {
+	path string
+	from interface{}
+	to   interface{}
+}
+
+func (c specChange) String() string {
+	return fmt.Sprintf("%s: %s → %s", c.path, changeValue(c.from), changeValue(c.to))
+}
+
+func changeValue(v interface{}) string {
+	if v == nil {
+		return "<none>"
+	}
+	s := fmt.Sprint(v)
+	if str, ok := v.(string); ok {
+		s = fmt.Sprintf("%q", str)
+	}
+	if len(s) > 60 {
+		s = s[:57] + "..."
+	}
+	return s
+}
+
+// specChanges compares two specs by their JSON form and returns the changed
+// fields. Fields managed by the server are ignored.
+func specChanges(live interface{}, desired interface{}) ([]specChange, error) {
+	from, err := toGeneric(normalizeSpec(live))
+	if err != nil {
+		return nil, err
+	}
+	to, err := toGeneric(normalizeSpec(desired))
+	if err != nil {
+		return nil, err
+	}
+	var changes []specChange
+	collectChanges("", from, to, &changes)
+	return changes, nil
+}
+
+func collectChanges(path string, from interface{}, to interface{}, changes *[]specChange) {
+	fromMap, fromIsMap := from.(map[string]interface{})
+	toMap, toIsMap := to.(map[string]interface{})
+	if fromIsMap && toIsMap {
+		keys := map[string]bool{}
+		for k := range fromMap {
+			keys[k] = true
+		}
+		for k := range toMap {
+			keys[k] = true
+		}
+		sorted := make([]string, 0, len(keys))
+		for k := range keys {
+			sorted = append(sorted, k)
+		}
+		sort.Strings(sorted)
+		for _, k := range sorted {
+			p := k
+			if path != "" {
+				p = path + "." + k
+			}
+			collectChanges(p, fromMap[k], toMap[k], changes)
+		}
+		return
+	}
+
+	fromList, fromIsList := from.([]interface{})
+	toList, toIsList := to.([]interface{})
+	if fromIsList && toIsList && len(fromList) == len(toList) {
+		for i := range fromList {
+			collectChanges(fmt.Sprintf("%s[%d]", path, i), fromList[i], toList[i], changes)
+		}
+		return
+	}
+
+	// an empty list and no list at all are the same for the server
+	if isEmpty(from) && isEmpty(to) {
+		return
+	}
+	if !reflect.DeepEqual(from, to) {
+		*changes = append(*changes, specChange{path: path, from: from, to: to})
+	}
+}
+
+func isEmpty(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case []interface{}:
+		return len(v) == 0
+	}
+	return false
+}
@@ -0,0 +1,452 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+	"k8s.io/client-go/util/jsonpath"
+)
+
+// output formats of the --output flag. jsonpath and go-template take their
+// template after a "=".
+const outputFormats = "table, wide, json, yaml, name, jsonpath=..., go-template=..., custom-columns=..., csv, markdown"
+
+// options of the list commands supporting --sort-by and --filter
+var sortBy string
+var listFilters []string
+
+func addListFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&sortBy, "sort-by", "", "Sort by a field, e.g. name or .git.repository.ssh_url")
+	cmd.Flags().StringArrayVar(&listFilters, "filter", []string{}, "Only list items matching field=value or field!=value, * matches any text")
+}
+
+// outputColumn is a column of the table output of a list.
+type outputColumn struct {
+	header string
+	// wide columns are only shown with -o wide
+	wide  bool
+	value func(item interface{}) string
+}
+
+// outputList is a typed API response which can be printed in all output
+// formats. The table formats show one row per item, the other formats render
+// data.
+type outputList struct {
+	data    interface{}
+	items   []interface{}
+	columns []outputColumn
+	name    func(item interface{}) string
+	// style optionally adjusts the table, e.g. removes the border
+	style func(table *tablewriter.Table)
+	// fields are short names of fields for --sort-by and --filter
+	fields map[string]string
+	// dataOf returns data with only the given items, after sorting and
+	// filtering
+	dataOf func(items []interface{}) interface{}
+}
+
+// parseOutputFormat splits the --output flag into the format and its
+// argument, e.g. "jsonpath={.items[*].name}".
+func parseOutputFormat() (string, string, error) {
+	format, arg, _ := strings.Cut(outputFormat, "=")
+	switch format {
+	case "table", "wide", "json", "yaml", "name", "csv", "markdown":
+		if arg != "" {
+			return "", "", fmt.Errorf("output format %s takes no argument", format)
+		}
+	case "jsonpath", "go-template", "custom-columns":
+		if arg == "" {
+			return "", "", fmt.Errorf("output format %s needs a template, e.g. -o %s=...", format, format)
+		}
+	default:
+		return "", "", fmt.Errorf("unknown output format '%s', use one of %s", outputFormat, outputFormats)
+	}
+	return format, arg, nil
+}
+
+func validateOutputFormat() {
+	if _, _, err := parseOutputFormat(); err != nil {
+		exitWithError(err)
+	}
+}
+
+// printList prints a list in the format selected by --output.
+func printList(list outputList) {
+	format, arg, err := parseOutputFormat()
+	if err != nil {
+		exitWithError(err)
+	}
+	if err := sortAndFilter(&list); err != nil {
+		exitWithError(err)
+	}
+
+	switch format {
+	case "custom-columns":
+		columns, err := parseCustomColumns(arg)
+		if err != nil {
+			exitWithError(err)
+		}
+		list.columns = columns
+		fallthrough
+	case "table", "wide":
+		table := tablewriter.NewWriter(os.Stdout)
+		if list.style != nil {
+			list.style(table)
+		}
+		headers, rows := list.rows(format == "wide")
+		table.SetHeader(headers)
+		table.AppendBulk(rows)
+		table.Render()
+	case "csv":
+		if err := printCSV(list.rows(false)); err != nil {
+			exitWithError(err)
+		}
+	case "markdown":
+		printMarkdown(list.rows(false))
+	case "name":
+		for _, item := range list.items {
+			fmt.Println(list.name(item))
+		}
+	default:
+		if err := printData(format, arg, list.data); err != nil {
+			exitWithError(err)
+		}
+	}
+}
+
+// rows returns the headers and rows of the table of a list. The columns are
+// always in the order they are defined in.
+func (list *outputList) rows(wide bool) ([]string, [][]string) {
+	var columns []outputColumn
+	for _, column := range list.columns {
+		if wide || !column.wide {
+			columns = append(columns, column)
+		}
+	}
+
+	headers := make([]string, len(columns))
+	for i, column := range columns {
+		headers[i] = column.header
+	}
+	rows := make([][]string, len(list.items))
+	for i, item := range list.items {
+		rows[i] = make([]string, len(columns))
+		for j, column := range columns {
+			rows[i][j] = column.value(item)
+		}
+	}
+	return headers, rows
+}
+
+// printCSV prints a table as RFC 4180 CSV with a header line.
+func printCSV(headers []string, rows [][]string) error {
+	w := csv.NewWriter(os.Stdout)
+	w.Write(headers)
+	w.WriteAll(rows)
+	return w.Error()
+}
+
+// printMarkdown prints a table as GitHub flavored markdown table.
+func printMarkdown(headers []string, rows [][]string) {
+	printMarkdownRow(headers)
+	separators := make([]string, len(headers))
+	for i := range separators {
+		separators[i] = "---"
+	}
+	fmt.Println("| " + strings.Join(separators, " | ") + " |")
+	for _, row := range rows {
+		printMarkdownRow(row)
+	}
+}
+
+var markdownEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	"|", `\|`,
+	"\r\n", "<br>",
+	"\n", "<br>",
+)
+
+func printMarkdownRow(cells []string) {
+	escaped := make([]string, len(cells))
+	for i, cell := range cells {
+		escaped[i] = markdownEscaper.Replace(cell)
+	}
+	fmt.Println("| " + strings.Join(escaped, " | ") + " |")
+}
+
+// printCLI prints a single object, as table or in one of the data formats.
+func printCLI(table *tablewriter.Table, v interface{}) {
+	printObject(v, table.Render)
+}
+
+// printObject prints a single object like printCLI, with render printing
+// the table formats.
+func printObject(v interface{}, render func()) {
+	format, arg, err := parseOutputFormat()
+	if err != nil {
+		exitWithError(err)
+	}
+
+	switch format {
+	case "table", "wide":
+		render()
+	case "name", "custom-columns", "csv", "markdown":
+		exitWithError(fmt.Errorf("output format %s is only supported by lists", format))
+	default:
+		if err := printData(format, arg, v); err != nil {
+			exitWithError(err)
+		}
+	}
+}
+
+// printData prints v as json, yaml, jsonpath or go-template. All of them see
+// the JSON field names of the typed structs.
+func printData(format string, arg string, v interface{}) error {
+	if format == "json" {
+		out, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(out))
+		return nil
+	}
+
+	data, err := toGeneric(v)
+	if err != nil {
+		return err
+	}
+
+	var out bytes.Buffer
+	switch format {
+	case "yaml":
+		encoder := yaml.NewEncoder(&out)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(data); err != nil {
+			return err
+		}
+	case "jsonpath":
+		// like kubectl, the braces may be left out for a single expression
+		if !strings.Contains(arg, "{") {
+			arg = "{" + arg + "}"
+		}
+		jp := jsonpath.New("output")
+		if err := jp.Parse(arg); err != nil {
+			return fmt.Errorf("invalid jsonpath template: %w", err)
+		}
+		if err := jp.Execute(&out, data); err != nil {
+			return fmt.Errorf("jsonpath: %w", err)
+		}
+	case "go-template":
+		tmpl, err := template.New("output").Parse(arg)
+		if err != nil {
+			return fmt.Errorf("invalid go-template: %w", err)
+		}
+		if err := tmpl.Execute(&out, data); err != nil {
+			return fmt.Errorf("go-template: %w", err)
+		}
+	}
+	if !bytes.HasSuffix(out.Bytes(), []byte("\n")) {
+		out.WriteString("\n")
+	}
+	_, err = os.Stdout.Write(out.Bytes())
+	return err
+}
+
+// toGeneric converts a typed value to maps and slices with its JSON field
+// names.
+func toGeneric(v interface{}) (interface{}, error) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	err = json.Unmarshal(data, &generic)
+	return generic, err
+}
+
+// relaxedJSONPath accepts JSONPath expressions without braces and the
+// leading dot, e.g. "name" for "{.name}".
+func relaxedJSONPath(expr string) string {
+	if strings.HasPrefix(expr, "{") {
+		return expr
+	}
+	if !strings.HasPrefix(expr, ".") {
+		expr = "." + expr
+	}
+	return "{" + expr + "}"
+}
+
+func parseJSONPath(expr string) (*jsonpath.JSONPath, error) {
+	jp := jsonpath.New(expr).AllowMissingKeys(true)
+	if err := jp.Parse(relaxedJSONPath(expr)); err != nil {
+		return nil, fmt.Errorf("invalid field '%s': %w", expr, err)
+	}
+	return jp, nil
+}
+
+// jsonPathValues returns the values jp finds in item as text.
+func jsonPathValues(jp *jsonpath.JSONPath, item interface{}) ([]string, error) {
+	data, err := toGeneric(item)
+	if err != nil {
+		return nil, err
+	}
+	results, err := jp.FindResults(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var values []string
+	for _, result := range results {
+		for _, value := range result {
+			switch v := value.Interface().(type) {
+			case nil:
+			case string:
+				values = append(values, v)
+			case map[string]interface{}, []interface{}:
+				out, _ := json.Marshal(v)
+				values = append(values, string(out))
+			default:
+				values = append(values, fmt.Sprint(v))
+			}
+		}
+	}
+	return values, nil
+}
+
+// parseCustomColumns parses the columns of -o custom-columns=NAME:.field,...
+func parseCustomColumns(spec string) ([]outputColumn, error) {
+	var columns []outputColumn
+	for _, def := range strings.Split(spec, ",") {
+		header, expr, found := strings.Cut(def, ":")
+		if !found || header == "" || expr == "" {
+			return nil, fmt.Errorf("invalid custom column '%s', expected NAME:.field", def)
+		}
+		jp, err := parseJSONPath(expr)
+		if err != nil {
+			return nil, err
+		}
+		columns = append(columns, outputColumn{header: header, value: func(item interface{}) string {
+			values, err := jsonPathValues(jp, item)
+			if err != nil || len(values) == 0 {
+				return "<none>"
+			}
+			return strings.Join(values, ",")
+		}})
+	}
+	return columns, nil
+}
+
+// fieldPath resolves a field of --sort-by or --filter, which is either a
+// short name of the list or a JSONPath expression.
+func (list *outputList) fieldPath(field string) (*jsonpath.JSONPath, error) {
+	if path, ok := list.fields[field]; ok {
+		field = path
+	}
+	return parseJSONPath(field)
+}
+
+// sortAndFilter applies --filter and --sort-by to the items of a list.
+func sortAndFilter(list *outputList) error {
+	if sortBy == "" && len(listFilters) == 0 {
+		return nil
+	}
+
+	for _, filter := range listFilters {
+		field, pattern, found := strings.Cut(filter, "=")
+		negate := strings.HasSuffix(field, "!")
+		field = strings.TrimSuffix(field, "!")
+		if !found || field == "" {
+			return fmt.Errorf("invalid filter '%s', expected field=value or field!=value", filter)
+		}
+		glob := globPattern(pattern)
+		jp, err := list.fieldPath(field)
+		if err != nil {
+			return err
+		}
+
+		items := []interface{}{}
+		for _, item := range list.items {
+			values, err := jsonPathValues(jp, item)
+			if err != nil {
+				return err
+			}
+			if matchesAny(glob, values) != negate {
+				items = append(items, item)
+			}
+		}
+		list.items = items
+	}
+
+	if sortBy != "" {
+		jp, err := list.fieldPath(sortBy)
+		if err != nil {
+			return err
+		}
+		keys := make([]string, len(list.items))
+		for i, item := range list.items {
+			values, err := jsonPathValues(jp, item)
+			if err != nil {
+				return err
+			}
+			keys[i] = strings.Join(values, ",")
+		}
+		order := make([]int, len(list.items))
+		for i := range order {
+			order[i] = i
+		}
+		sort.SliceStable(order, func(a, b int) bool {
+			return lessValue(keys[order[a]], keys[order[b]])
+		})
+		sorted := make([]interface{}, len(list.items))
+		for i, index := range order {
+			sorted[i] = list.items[index]
+		}
+		list.items = sorted
+	}
+
+	if list.dataOf != nil {
+		list.data = list.dataOf(list.items)
+	}
+	return nil
+}
+
+// globPattern converts a filter value with * and ? as wildcards to a case
+// insensitive regexp matching the whole value. Unlike path.Match, * also
+// matches slashes, which are common in repository URLs and images.
+func globPattern(pattern string) *regexp.Regexp {
+	quoted := regexp.QuoteMeta(pattern)
+	quoted = strings.ReplaceAll(quoted, `\*`, ".*")
+	quoted = strings.ReplaceAll(quoted, `\?`, ".")
+	return regexp.MustCompile("(?is)^" + quoted + "$")
+}
+
+// matchesAny reports whether one of the values matches the glob.
+func matchesAny(glob *regexp.Regexp, values []string) bool {
+	for _, value := range values {
+		if glob.MatchString(value) {
+			return true
+		}
+	}
+	return false
+}
+
+// lessValue compares numbers numerically and everything else as text.
+func lessValue(a string, b string) bool {
+	numA, errA := strconv.ParseFloat(a, 64)
+	numB, errB := strconv.ParseFloat(b, 64)
+	if errA == nil && errB == nil {
+		return numA < numB
+	}
+	return strings.ToLower(a) < strings.ToLower(b)
+}
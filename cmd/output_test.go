@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"reflect"
+	"testing"
+)
+
+// captureStdout returns what print writes to os.Stdout.
+func captureStdout(t *testing.T, print func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stdout := os.Stdout
+	os.Stdout = w
+	defer func() { os.Stdout = stdout }()
+
+	print()
+	w.Close()
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
+
+func TestGlobPattern(t *testing.T) {
+	tests := []struct {
+		pattern string
+		value   string
+		match   bool
+	}{
+		{"web", "web", true},
+		{"web", "WEB", true},
+		{"web", "web-1", false},
+		{"web*", "web-1", true},
+		{"*acme*", "git@github.com:acme/shop.git", true},
+		{"ghcr.io/*", "ghcr.io/acme/shop:1.0", true},
+		{"shop-?", "shop-1", true},
+		{"shop-?", "shop-12", false},
+		{"1.0", "1x0", false},
+		{"a+b", "a+b", true},
+		{"(x)", "(x)", true},
+		{"*", "multi\nline", true},
+	}
+
+	for _, tt := range tests {
+		if got := globPattern(tt.pattern).MatchString(tt.value); got != tt.match {
+			t.Errorf("%q matches %q = %v, want %v", tt.pattern, tt.value, got, tt.match)
+		}
+	}
+}
+
+func TestSortAndFilter(t *testing.T) {
+	items := []interface{}{
+		map[string]interface{}{"name": "web", "repo": "ghcr.io/acme/web", "replicas": 10},
+		map[string]interface{}{"name": "worker", "repo": "ghcr.io/acme/worker", "replicas": 2},
+		map[string]interface{}{"name": "cron", "repo": "docker.io/library/alpine", "replicas": 1},
+	}
+	tests := []struct {
+		name    string
+		filters []string
+		sortBy  string
+		want    []string
+		wantErr bool
+	}{
+		{name: "no options", want: []string{"web", "worker", "cron"}},
+		{name: "filter by short name", filters: []string{"name=w*"}, want: []string{"web", "worker"}},
+		{name: "case insensitive filter", filters: []string{"name=WEB"}, want: []string{"web"}},
+		{name: "filter across slashes", filters: []string{"repo=*acme*"}, want: []string{"web", "worker"}},
+		{name: "negated filter", filters: []string{"name!=w*"}, want: []string{"cron"}},
+		{name: "negated filter across slashes", filters: []string{"repo!=ghcr.io/*"}, want: []string{"cron"}},
+		{name: "several filters", filters: []string{"name=w*", "name!=web"}, want: []string{"worker"}},
+		{name: "sort by text", sortBy: "name", want: []string{"cron", "web", "worker"}},
+		{name: "sort numerically", sortBy: ".replicas", want: []string{"cron", "worker", "web"}},
+		{name: "filter and sort", filters: []string{"name=w*"}, sortBy: "replicas", want: []string{"worker", "web"}},
+		{name: "invalid filter", filters: []string{"name"}, wantErr: true},
+	}
+
+	defer func() {
+		listFilters = nil
+		sortBy = ""
+	}()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			listFilters = tt.filters
+			sortBy = tt.sortBy
+			list := &outputList{
+				items:  items,
+				fields: map[string]string{"name": ".name", "repo": ".repo", "replicas": ".replicas"},
+				dataOf: func(items []interface{}) interface{} { return items },
+			}
+
+			err := sortAndFilter(list)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("no error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			names := []string{}
+			for _, item := range list.items {
+				names = append(names, item.(map[string]interface{})["name"].(string))
+			}
+			if !reflect.DeepEqual(names, tt.want) {
+				t.Errorf("got %v, want %v", names, tt.want)
+			}
+			if tt.filters != nil && len(list.data.([]interface{})) != len(tt.want) {
+				t.Errorf("data was not filtered")
+			}
+		})
+	}
+}
+
+func TestPrintCSV(t *testing.T) {
+	out := captureStdout(t, func() {
+		err := printCSV([]string{"NAME", "NOTE"}, [][]string{
+			{"web", "plain"},
+			{"a,b", `say "hi"`},
+			{"multi", "line 1\nline 2"},
+		})
+		if err != nil {
+			t.Error(err)
+		}
+	})
+	want := "NAME,NOTE\nweb,plain\n\"a,b\",\"say \"\"hi\"\"\"\nmulti,\"line 1\nline 2\"\n"
+	if out != want {
+		t.Errorf("got\n%s\nwant\n%s", out, want)
+	}
+}
+
+func TestPrintMarkdown(t *testing.T) {
+	out := captureStdout(t, func() {
+		printMarkdown([]string{"NAME", "NOTE"}, [][]string{
+			{"web", "plain"},
+			{"a|b", `C:\path`},
+			{"multi", "line 1\nline 2\r\nline 3"},
+		})
+	})
+	want := "| NAME | NOTE |\n" +
+		"| --- | --- |\n" +
+		"| web | plain |\n" +
+		"| a\\|b | C:\\\\path |\n" +
+		"| multi | line 1<br>line 2<br>line 3 |\n"
+	if out != want {
+		t.Errorf("got\n%s\nwant\n%s", out, want)
+	}
+}
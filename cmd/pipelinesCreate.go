@@ -1,10 +1,9 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
+	"kubero/pkg/kuberoapi"
 	"os"
-	"time"
 
 	"github.com/i582/cfmt/cmd/cfmt"
 	"github.com/spf13/cobra"
@@ -19,116 +18,46 @@ var PipelineCreateCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		fmt.Println("create a new pipeline")
 
-		loadRepositories()
-		loadContexts()
-		loadBuildpacks()
+		switch deploymentStrategy {
+		case "", kuberoapi.StrategyGit, kuberoapi.StrategyDocker:
+		default:
+			exitWithError(fmt.Errorf("invalid --strategy %q, expected git or docker", deploymentStrategy))
+		}
+
+		loadRepositories(cmd.Context())
+		loadContexts(cmd.Context())
+		loadBuildpacks(cmd.Context())
 		createPipeline := pipelinesForm()
+		if createPipeline.Spec.Deploymentstrategy == kuberoapi.StrategyDocker && createPipeline.Spec.Dockerimage == "" {
+			exitWithError(fmt.Errorf("the docker strategy needs an image, use --image"))
+		}
 
-		client.SetBody(createPipeline.Spec)
-		pipeline, pipelineErr := client.Post("/api/cli/pipelines/")
+		pipeline, pipelineErr := client.CreatePipeline(cmd.Context(), &createPipeline.Spec)
 
 		if pipelineErr != nil {
-			fmt.Println(pipelineErr)
+			exitWithError(pipelineErr)
 		} else {
 			cfmt.Println("{{Pipeline created successfully}}::green")
-			json.Unmarshal(pipeline.Body(), &createPipeline.Spec)
+			pullSecret := createPipeline.Spec.ImagePullSecret
+			createPipeline.Spec = *pipeline
+			createPipeline.Spec.ImagePullSecret = pullSecret
 			writePipelineYaml(createPipeline)
 		}
 
 	},
 }
 
+var deploymentStrategy string
+var dockerImage string
+
 func init() {
+	PipelineCreateCmd.Flags().StringVar(&deploymentStrategy, "strategy", "", "Deployment strategy [git,docker], docker deploys images built elsewhere")
+	PipelineCreateCmd.Flags().StringVar(&dockerImage, "image", "", "Image repository of the docker strategy, e.g. ghcr.io/org/app")
+	PipelineCreateCmd.Flags().StringVar(&pullSecret, "pull-secret", "", "Image pull secret of the apps for a private registry")
 	pipelinesCmd.AddCommand(PipelineCreateCmd)
 }
 
-type CreatePipeline struct {
-	APIVersion string `json:"apiVersion"`
-	Kind       string `json:"kind"`
-	Spec       struct {
-		Buildpack struct {
-			Build struct {
-				Command    string `json:"command"`
-				Repository string `json:"repository"`
-				Tag        string `json:"tag"`
-			} `json:"build"`
-			Fetch struct {
-				Repository string `json:"repository"`
-				Tag        string `json:"tag"`
-			} `json:"fetch"`
-			Language string `json:"language"`
-			Name     string `json:"name"`
-			Run      struct {
-				Command    string `json:"command"`
-				Repository string `json:"repository"`
-				Tag        string `json:"tag"`
-			} `json:"run"`
-		} `json:"buildpack"`
-		Deploymentstrategy string `json:"deploymentstrategy"`
-		Dockerimage        string `json:"dockerimage"`
-		Git                struct {
-			Keys struct {
-				CreatedAt time.Time `json:"created_at"`
-				ID        int       `json:"id"`
-				//Priv      string    `json:"priv"`
-				//Pub       string    `json:"pub"`
-				ReadOnly bool   `json:"read_only"`
-				Title    string `json:"title"`
-				URL      string `json:"url"`
-				Verified bool   `json:"verified"`
-			} `json:"keys"`
-			Repository struct {
-				Provider      string `json:"provider"`
-				Admin         bool   `json:"admin"`
-				CloneURL      string `json:"clone_url"`
-				DefaultBranch string `json:"default_branch"`
-				Description   string `json:"description"`
-				Homepage      string `json:"homepage"`
-				ID            int    `json:"id"`
-				Language      string `json:"language"`
-				Name          string `json:"name"`
-				NodeID        string `json:"node_id"`
-				Owner         string `json:"owner"`
-				Private       bool   `json:"private"`
-				Push          bool   `json:"push"`
-				SSHURL        string `json:"ssh_url"`
-				Visibility    string `json:"visibility"`
-			} `json:"repository"`
-			Webhook struct {
-				Active    bool      `json:"active"`
-				CreatedAt time.Time `json:"created_at"`
-				Events    []string  `json:"events"`
-				ID        int       `json:"id"`
-				Insecure  string    `json:"insecure"`
-				URL       string    `json:"url"`
-			} `json:"webhook"`
-			Webhooks struct {
-			} `json:"webhooks"`
-		} `json:"git"`
-		Name       string  `json:"pipelineName"`
-		Phases     []Phase `json:"phases"`
-		Reviewapps bool    `json:"reviewapps"`
-	} `json:"spec"`
-}
-
-type CreatePipelines struct {
-	PipelineName       string  `json:"pipelineName"`
-	RepoProvider       string  `json:"repoprovider"`
-	RepositoryURL      string  `json:"repositoryURL"`
-	Phases             []Phase `json:"phases"`
-	Reviewapps         bool    `json:"reviewapps"`
-	Dockerimage        string  `json:"dockerimage"`
-	Deploymentstrategy string  `json:"deploymentstrategy"`
-	Buildpack          string  `json:"buildpack"`
-}
-
-type Phase struct {
-	Name    string `json:"name"`
-	Enabled bool   `json:"enabled"`
-	Context string `json:"context"`
-}
-
-func writePipelineYaml(pipeline CreatePipeline) {
+func writePipelineYaml(pipeline kuberoapi.CreatePipeline) {
 	// write pipeline.yaml
 	yamlData, err := yaml.Marshal(&pipeline)
 
@@ -144,17 +73,13 @@ func writePipelineYaml(pipeline CreatePipeline) {
 	}
 }
 
-func pipelinesForm() CreatePipeline {
+func pipelinesForm() kuberoapi.CreatePipeline {
 
-	var cp CreatePipeline
+	var cp kuberoapi.CreatePipeline
 
 	cp.APIVersion = "application.kubero.dev/v1alpha1"
 	cp.Kind = "KuberoPipeline"
 
-	// those fields are deprecated and may be removed in the future
-	cp.Spec.Dockerimage = ""
-	cp.Spec.Deploymentstrategy = "git"
-
 	if pipeline == "" {
 		pipeline = pipelineConfig.GetString("spec.name")
 		cp.Spec.Name = promptLine("Pipeline Name", "", pipeline)
@@ -162,27 +87,48 @@ func pipelinesForm() CreatePipeline {
 		cp.Spec.Name = pipeline
 	}
 
-	gitPrivider := pipelineConfig.GetString("spec.git.repository.provider")
-	cp.Spec.Git.Repository.Provider = promptLine("Repository Provider", fmt.Sprint(repoSimpleList), gitPrivider)
+	if deploymentStrategy == "" {
+		strategyDefault := pipelineConfig.GetString("spec.deploymentstrategy")
+		if strategyDefault == "" {
+			strategyDefault = kuberoapi.StrategyGit
+		}
+		deploymentStrategy = promptLine("Deployment Strategy", "[git,docker]", strategyDefault)
+	}
+	cp.Spec.Deploymentstrategy = deploymentStrategy
 
-	gitURL := pipelineConfig.GetString("spec.git.repository.sshurl")
-	cp.Spec.Git.Repository.SSHURL = promptLine("Repository URL", "["+getGitRemote()+"]", gitURL)
+	if deploymentStrategy == kuberoapi.StrategyDocker {
+		// the images are built elsewhere, no repository or buildpack needed
+		if dockerImage == "" {
+			dockerImage = promptLine("Docker Image", "[registry/name]", pipelineConfig.GetString("spec.dockerimage"))
+		}
+		cp.Spec.Dockerimage = dockerImage
+		if pullSecret == "" {
+			pullSecret = promptLine("Image Pull Secret", "[empty for public images]", pipelineConfig.GetString("spec.imagepullsecret"))
+		}
+		cp.Spec.ImagePullSecret = pullSecret
+	} else {
+		gitPrivider := pipelineConfig.GetString("spec.git.repository.provider")
+		cp.Spec.Git.Repository.Provider = promptLine("Repository Provider", fmt.Sprint(repoSimpleList), gitPrivider)
 
-	selectedBuildpack := pipelineConfig.GetString("spec.buildpack.name")
-	cp.Spec.Buildpack.Name = promptLine("Buildpack ", fmt.Sprint(buildPacksSimpleList), selectedBuildpack)
+		gitURL := pipelineConfig.GetString("spec.git.repository.sshurl")
+		cp.Spec.Git.Repository.SSHURL = promptLine("Repository URL", "["+getGitRemote()+"]", gitURL)
+
+		selectedBuildpack := pipelineConfig.GetString("spec.buildpack.name")
+		cp.Spec.Buildpack.Name = promptLine("Buildpack ", fmt.Sprint(buildPacksSimpleList), selectedBuildpack)
+	}
 
 	phaseReview := promptLine("enable reviewapps", "[y,n]", "n")
 	if phaseReview == "y" {
 		cp.Spec.Reviewapps = true
 		contextDefault := pipelineConfig.GetString("spec.phases.0.context")
-		cp.Spec.Phases = append(cp.Spec.Phases, Phase{
+		cp.Spec.Phases = append(cp.Spec.Phases, kuberoapi.Phase{
 			Name:    "review",
 			Enabled: true,
 			Context: promptLine("Context for reviewapps", fmt.Sprint(contextSimpleList), contextDefault),
 		})
 	} else {
 		cp.Spec.Reviewapps = false
-		cp.Spec.Phases = append(cp.Spec.Phases, Phase{
+		cp.Spec.Phases = append(cp.Spec.Phases, kuberoapi.Phase{
 			Name:    "review",
 			Enabled: false,
 			Context: "",
@@ -192,13 +138,13 @@ func pipelinesForm() CreatePipeline {
 	phaseTest := promptLine("enable test", "[y,n]", "n")
 	if phaseTest == "y" {
 		contextDefault := pipelineConfig.GetString("spec.phases.1.context")
-		cp.Spec.Phases = append(cp.Spec.Phases, Phase{
+		cp.Spec.Phases = append(cp.Spec.Phases, kuberoapi.Phase{
 			Name:    "test",
 			Enabled: true,
 			Context: promptLine("Context for test", fmt.Sprint(contextSimpleList), contextDefault),
 		})
 	} else {
-		cp.Spec.Phases = append(cp.Spec.Phases, Phase{
+		cp.Spec.Phases = append(cp.Spec.Phases, kuberoapi.Phase{
 			Name:    "test",
 			Enabled: false,
 			Context: "",
@@ -208,13 +154,13 @@ func pipelinesForm() CreatePipeline {
 	phaseStage := promptLine("enable stage", "[y,n]", "n")
 	if phaseStage == "y" {
 		contextDefault := pipelineConfig.GetString("spec.phases.2.context")
-		cp.Spec.Phases = append(cp.Spec.Phases, Phase{
+		cp.Spec.Phases = append(cp.Spec.Phases, kuberoapi.Phase{
 			Name:    "stage",
 			Enabled: true,
 			Context: promptLine("Context for stage", fmt.Sprint(contextSimpleList), contextDefault),
 		})
 	} else {
-		cp.Spec.Phases = append(cp.Spec.Phases, Phase{
+		cp.Spec.Phases = append(cp.Spec.Phases, kuberoapi.Phase{
 			Name:    "stage",
 			Enabled: false,
 			Context: "",
@@ -225,13 +171,13 @@ func pipelinesForm() CreatePipeline {
 	//var phaseProductionContext string = ""
 	if phaseProduction != "n" {
 		contextDefault := pipelineConfig.GetString("spec.phases.3.context")
-		cp.Spec.Phases = append(cp.Spec.Phases, Phase{
+		cp.Spec.Phases = append(cp.Spec.Phases, kuberoapi.Phase{
 			Name:    "production",
 			Enabled: true,
 			Context: promptLine("Context for production ", fmt.Sprint(contextSimpleList), contextDefault),
 		})
 	} else {
-		cp.Spec.Phases = append(cp.Spec.Phases, Phase{
+		cp.Spec.Phases = append(cp.Spec.Phases, kuberoapi.Phase{
 			Name:    "production",
 			Enabled: false,
 			Context: "",
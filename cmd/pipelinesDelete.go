@@ -1,8 +1,7 @@
 package cmd
 
 import (
-	"fmt"
-
+	"github.com/i582/cfmt/cmd/cfmt"
 	"github.com/spf13/cobra"
 )
 
@@ -17,10 +16,14 @@ Cobra is a CLI library for Go that empowers applications.
 This application is a tool to generate the needed files
 to quickly create a Cobra application.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("delete called")
 
-		pipeline, _ := client.Delete("/api/cli/pipelines/" + pipeline)
-		fmt.Println(pipeline)
+		pipelineErr := client.DeletePipeline(cmd.Context(), pipeline)
+
+		if pipelineErr != nil {
+			exitWithError(pipelineErr)
+		} else {
+			cfmt.Println("{{Pipeline deleted successfully}}::green")
+		}
 	},
 }
 
@@ -1,8 +1,7 @@
 package cmd
 
 import (
-	"encoding/json"
-	"fmt"
+	"kubero/pkg/kuberoapi"
 
 	"github.com/spf13/cobra"
 )
@@ -14,14 +13,14 @@ var pipelinesFetchCmd = &cobra.Command{
 	Run: func(cmd *cobra.Command, args []string) {
 		createPipeline := pipelinesFetchForm()
 
-		client.SetBody(createPipeline.Spec)
-		p, pipelineErr := client.Get("/api/cli/pipelines/" + createPipeline.Spec.Name)
+		p, pipelineErr := client.GetPipelineSpec(cmd.Context(), createPipeline.Spec.Name)
 
 		if pipelineErr != nil {
-			fmt.Println(pipelineErr)
+			exitWithError(pipelineErr)
 		} else {
-			json.Unmarshal(p.Body(), &createPipeline.Spec)
-			//json.Unmarshal(p.Body(), &createPipeline)
+			createPipeline.Spec = *p
+			// only kept locally, see PipelineSpec.ImagePullSecret
+			createPipeline.Spec.ImagePullSecret = pipelineConfig.GetString("spec.imagepullsecret")
 			writePipelineYaml(createPipeline)
 		}
 	},
@@ -33,9 +32,9 @@ func init() {
 	pipelinesCmd.AddCommand(pipelinesFetchCmd)
 }
 
-func pipelinesFetchForm() CreatePipeline {
+func pipelinesFetchForm() kuberoapi.CreatePipeline {
 
-	var cp CreatePipeline
+	var cp kuberoapi.CreatePipeline
 
 	cp.APIVersion = "application.kubero.dev/v1alpha1"
 	cp.Kind = "KuberoPipeline"
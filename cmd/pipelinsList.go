@@ -1,13 +1,11 @@
 package cmd
 
 import (
-	"encoding/json"
 	"fmt"
-	"os"
+	"kubero/pkg/kuberoapi"
+	"strconv"
 
-	"github.com/go-resty/resty/v2"
 	"github.com/i582/cfmt/cmd/cfmt"
-	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 )
 
@@ -25,71 +23,98 @@ to quickly create a Cobra application.`,
 
 		if pipeline != "" {
 			// get a single pipeline
-			pipelineResp, _ := client.Get("/api/cli/pipelines/" + pipeline)
-			printPipeline(pipelineResp)
+			p, err := client.GetPipeline(cmd.Context(), pipeline)
+			if err != nil {
+				exitWithError(err)
+			}
+			printPipeline(p)
 		} else {
 			// get the pipelines
-			pipelineListResp, _ := client.Get("/api/cli/pipelines")
-			printPipelinesList(pipelineListResp)
+			pipelinesList, err := client.ListPipelines(cmd.Context())
+			if err != nil {
+				exitWithError(err)
+			}
+			printPipelinesList(pipelinesList)
 		}
 	},
 }
 
 func init() {
+	addListFlags(pipelinesListCmd)
 	pipelinesCmd.AddCommand(pipelinesListCmd)
 }
 
 // print the response as a table
-func printPipelinesList(r *resty.Response) {
+func printPipelinesList(pipelinesList *kuberoapi.PipelinesList) {
+	items := make([]interface{}, len(pipelinesList.Items))
+	for i, pipeline := range pipelinesList.Items {
+		items[i] = pipeline
+	}
 
-	table := tablewriter.NewWriter(os.Stdout)
-	table.SetHeader([]string{
-		"Name",
-		"Repository",
-		//"Branch",
-		"Buildpack",
-		"reviewapps",
-		"test",
-		"staging",
-		"production",
-		//"Docker Image",
-		//"Deployment Strategy",
-		//"Review Apps"
+	pipeline := func(item interface{}) kuberoapi.Pipeline { return item.(kuberoapi.Pipeline) }
+	phaseColumn := func(header string, phase string) outputColumn {
+		return outputColumn{header: header, value: func(item interface{}) string {
+			return strconv.FormatBool(phaseEnabled(pipeline(item), phase))
+		}}
+	}
+	printList(outputList{
+		data:  pipelinesList,
+		items: items,
+		columns: []outputColumn{
+			{header: "Name", value: func(item interface{}) string { return pipeline(item).Name }},
+			{header: "Repository", value: func(item interface{}) string { return pipeline(item).Git.Repository.SSHURL }},
+			{header: "Buildpack", value: func(item interface{}) string { return pipeline(item).Buildpack.Name }},
+			phaseColumn("reviewapps", "review"),
+			phaseColumn("test", "test"),
+			phaseColumn("staging", "stage"),
+			phaseColumn("production", "production"),
+			{header: "Branch", wide: true, value: func(item interface{}) string { return pipeline(item).Git.Repository.DefaultBranch }},
+			{header: "Deployment Strategy", wide: true, value: func(item interface{}) string { return pipeline(item).Deploymentstrategy }},
+			{header: "Docker Image", wide: true, value: func(item interface{}) string { return pipeline(item).Dockerimage }},
+		},
+		name: func(item interface{}) string { return pipeline(item).Name },
+		fields: map[string]string{
+			"buildpack":  ".buildpack.name",
+			"repository": ".git.repository.ssh_url",
+			"branch":     ".git.repository.default_branch",
+			"phase":      ".phases[?(@.enabled==true)].name",
+			"context":    ".phases[?(@.enabled==true)].context",
+		},
+		dataOf: func(items []interface{}) interface{} {
+			list := &kuberoapi.PipelinesList{Items: []kuberoapi.Pipeline{}}
+			for _, item := range items {
+				list.Items = append(list.Items, pipeline(item))
+			}
+			return list
+		},
 	})
-	//table.SetBorder(false)
-
-	var pipelinesList PipelinesList
-	json.Unmarshal(r.Body(), &pipelinesList)
+}
 
-	for _, pipeline := range pipelinesList.Items {
-		table.Append([]string{
-			pipeline.Name,
-			pipeline.Git.Repository.SSHURL,
-			//pipeline.Git.Repository.DefaultBranch,
-			pipeline.Buildpack.Name,
-			//pipeline.Dockerimage,
-			//pipeline.Deploymentstrategy,
-			//fmt.Sprintf("%t", pipeline.Reviewapps)
-			fmt.Sprintf("%t", pipeline.Phases[0].Enabled),
-			fmt.Sprintf("%t", pipeline.Phases[1].Enabled),
-			fmt.Sprintf("%t", pipeline.Phases[2].Enabled),
-			fmt.Sprintf("%t", pipeline.Phases[3].Enabled),
-		})
+func phaseEnabled(pipeline kuberoapi.Pipeline, name string) bool {
+	for _, phase := range pipeline.Phases {
+		if phase.Name == name {
+			return phase.Enabled
+		}
 	}
-
-	printCLI(table, r)
+	return false
 }
 
-func printPipeline(r *resty.Response) {
-	//fmt.Println(r)
-
-	var pipeline Pipeline
-	json.Unmarshal(r.Body(), &pipeline)
+func printPipeline(pipeline *kuberoapi.Pipeline) {
+	if format, _, err := parseOutputFormat(); err != nil {
+		exitWithError(err)
+	} else if format != "table" && format != "wide" {
+		printList(outputList{
+			data:  pipeline,
+			items: []interface{}{*pipeline},
+			name:  func(item interface{}) string { return pipeline.Name },
+		})
+		return
+	}
 
 	cfmt.Printf("{{Name:}}::lightWhite %v \n", pipeline.Name)
 	cfmt.Printf("{{Buildpack:}}::lightWhite %v, %v \n", pipeline.Buildpack.Name, pipeline.Buildpack.Language)
 	if pipeline.Dockerimage != "" {
-		fmt.Printf("{{Docker Image:}}::lightWhite %v \n", pipeline.Dockerimage)
+		cfmt.Printf("{{Docker Image:}}::lightWhite %v \n", pipeline.Dockerimage)
 	}
 	cfmt.Printf("{{Deployment Strategy:}}::lightWhite %v \n", pipeline.Deploymentstrategy)
 	cfmt.Printf("{{Git:}}::lightWhite %v:%v \n", pipeline.Git.Repository.SSHURL, pipeline.Git.Repository.DefaultBranch)
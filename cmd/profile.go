@@ -0,0 +1,291 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/i582/cfmt/cmd/cfmt"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+// profileCmd represents the profile command
+var profileCmd = &cobra.Command{
+	Use:   "profile",
+	Short: "Manage the Kubero servers the CLI talks to",
+	Long: `Manage the Kubero servers the CLI talks to.
+
+A profile holds the api settings of a Kubero server, its token is saved to
+the credential store (see 'kubero login --help'). The active profile
+is selected with the --profile flag, the KUBERO_PROFILE environment variable or
+'kubero profile use', in this order. Without an active profile the top level
+api settings are used.`,
+	// profiles must be manageable even if the active one is broken
+	PersistentPreRun: preRunWithoutClient,
+}
+
+var profileAddCmd = &cobra.Command{
+	Use:   "add <name>",
+	Short: "Add a profile or update an existing one",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := profileNameArg(args[0])
+
+		if profileURL == "" {
+			profileURL = promptLine("Kubero Host adress", "", viper.GetString("profiles."+name+".api.url"))
+		}
+		if profileToken == "" {
+			profileToken = promptSecret("Kubero Token")
+		}
+
+		personal := loadPersonalConfig()
+		api := personalAPIConfig(personal, name)
+		api["url"] = profileURL
+		if err := saveToken(api, name, profileToken); err != nil {
+			exitWithError(err)
+		}
+		if personal["currentprofile"] == nil || personal["currentprofile"] == "" {
+			personal["currentprofile"] = name
+		}
+
+		if err := writePersonalConfig(personal); err != nil {
+			exitWithError(err)
+		}
+		cfmt.Println("{{Profile " + name + " saved}}::green")
+	},
+}
+
+var profileListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List the profiles",
+	Run: func(cmd *cobra.Command, args []string) {
+		printProfiles(activeProfile())
+	},
+}
+
+var profileUseCmd = &cobra.Command{
+	Use:   "use <name>",
+	Short: "Make a profile the active one",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := profileNameArg(args[0])
+		if !viper.IsSet("profiles." + name) {
+			exitWithError(fmt.Errorf("profile %s does not exist", name))
+		}
+
+		personal := loadPersonalConfig()
+		personal["currentprofile"] = name
+		if err := writePersonalConfig(personal); err != nil {
+			exitWithError(err)
+		}
+		cfmt.Println("{{Switched to profile " + name + "}}::green")
+	},
+}
+
+var profileDeleteCmd = &cobra.Command{
+	Use:   "delete <name>",
+	Short: "Delete a profile",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		name := profileNameArg(args[0])
+
+		personal := loadPersonalConfig()
+		profiles := personalProfiles(personal)
+		if _, ok := profiles[name]; !ok {
+			exitWithError(fmt.Errorf("profile %s does not exist in %s", name, personalConfigFile()))
+		}
+		delete(profiles, name)
+		if err := eraseToken(name); err != nil {
+			exitWithError(err)
+		}
+		if personal["currentprofile"] == name {
+			delete(personal, "currentprofile")
+		}
+
+		if err := writePersonalConfig(personal); err != nil {
+			exitWithError(err)
+		}
+		cfmt.Println("{{Profile " + name + " deleted}}::green")
+	},
+}
+
+var profileName string
+var profileURL string
+var profileToken string
+
+func init() {
+	rootCmd.PersistentFlags().StringVar(&profileName, "profile", "", "Name of the profile to use (env KUBERO_PROFILE)")
+
+	profileAddCmd.Flags().StringVar(&profileURL, "url", "", "URL of the Kubero UI")
+	profileAddCmd.Flags().StringVar(&profileToken, "token", "", "API token")
+
+	profileCmd.AddCommand(profileAddCmd)
+	profileCmd.AddCommand(profileListCmd)
+	profileCmd.AddCommand(profileUseCmd)
+	profileCmd.AddCommand(profileDeleteCmd)
+	rootCmd.AddCommand(profileCmd)
+}
+
+// activeProfile returns the name of the selected profile or an empty string
+// if the top level api settings should be used.
+func activeProfile() string {
+	if profileName != "" {
+		return strings.ToLower(profileName)
+	}
+	if env := os.Getenv("KUBERO_PROFILE"); env != "" {
+		return strings.ToLower(env)
+	}
+	return viper.GetString("currentProfile")
+}
+
+// configString returns a setting of the active profile, falling back to the
+// top level setting if the profile does not define it.
+func configString(key string) string {
+	return lookupConfigString(viper.GetViper(), key)
+}
+
+func lookupConfigString(config *viper.Viper, key string) string {
+	if profile := activeProfile(); profile != "" && config.IsSet("profiles."+profile+"."+key) {
+		return config.GetString("profiles." + profile + "." + key)
+	}
+	return config.GetString(key)
+}
+
+// systemConfigFile is the config file shared by all users.
+const systemConfigFile = "/etc/kubero/kubero.yaml"
+
+// ignoredProjectSettings remembers the warnings already printed.
+var ignoredProjectSettings = map[string]bool{}
+
+// trustedConfigString is configString ignoring the kubero.yaml of the
+// current directory. It is used for settings which run commands or weaken
+// the connection security, since that file may come with any cloned repo.
+// A warning is printed if that file sets one of them to another value.
+func trustedConfigString(key string) string {
+	trusted := readConfigFile(systemConfigFile)
+	trusted.MergeConfigMap(loadPersonalConfig())
+	value := lookupConfigString(trusted, key)
+
+	projectFile := viper.ConfigFileUsed()
+	if projectFile == "" || ignoredProjectSettings[key] {
+		return value
+	}
+	// false and empty values are the defaults, ignoring them changes nothing
+	if project := lookupConfigString(readConfigFile(projectFile), key); project != "" && project != "false" && project != value {
+		ignoredProjectSettings[key] = true
+		cfmt.Fprintln(os.Stderr, "{{⚠ Ignoring "+key+" of "+projectFile+", set it in "+personalConfigFile()+"}}::yellow")
+	}
+	return value
+}
+
+// checkActiveProfile fails if a profile is selected which is not configured.
+func checkActiveProfile() {
+	if profile := activeProfile(); profile != "" && !viper.IsSet("profiles."+profile) {
+		exitWithError(fmt.Errorf("profile %s does not exist, see 'kubero profile list'", profile))
+	}
+}
+
+// viper keys are case insensitive, so profile names are as well
+func profileNameArg(name string) string {
+	name = strings.ToLower(name)
+	if strings.Contains(name, ".") {
+		exitWithError(fmt.Errorf("profile names must not contain dots"))
+	}
+	return name
+}
+
+type profileInfo struct {
+	Name   string `json:"name"`
+	URL    string `json:"url"`
+	Active bool   `json:"active"`
+}
+
+func printProfiles(active string) {
+	names := make([]string, 0)
+	for name := range viper.GetStringMap("profiles") {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"", "Name", "URL"})
+	table.SetBorder(false)
+
+	var profiles []profileInfo
+	for _, name := range names {
+		info := profileInfo{
+			Name:   name,
+			URL:    viper.GetString("profiles." + name + ".api.url"),
+			Active: name == active,
+		}
+		profiles = append(profiles, info)
+
+		marker := ""
+		if info.Active {
+			marker = "*"
+		}
+		table.Append([]string{marker, info.Name, info.URL})
+	}
+
+	printCLI(table, profiles)
+}
+
+// personalConfigFile is the per user config file in which profiles are saved.
+func personalConfigFile() string {
+	home, _ := os.UserHomeDir()
+	return filepath.Join(home, ".kubero", "kubero.yaml")
+}
+
+func loadPersonalConfig() map[string]interface{} {
+	return readConfigFile(personalConfigFile()).AllSettings()
+}
+
+func personalProfiles(personal map[string]interface{}) map[string]interface{} {
+	profiles, ok := personal["profiles"].(map[string]interface{})
+	if !ok {
+		profiles = map[string]interface{}{}
+		personal["profiles"] = profiles
+	}
+	return profiles
+}
+
+// personalAPIConfig returns the api section of the given profile, or the top
+// level one if profile is empty, creating it if needed.
+func personalAPIConfig(personal map[string]interface{}, profile string) map[string]interface{} {
+	parent := personal
+	if profile != "" {
+		profiles := personalProfiles(personal)
+		p, ok := profiles[profile].(map[string]interface{})
+		if !ok {
+			p = map[string]interface{}{}
+			profiles[profile] = p
+		}
+		parent = p
+	}
+
+	api, ok := parent["api"].(map[string]interface{})
+	if !ok {
+		api = map[string]interface{}{}
+		parent["api"] = api
+	}
+	return api
+}
+
+func writePersonalConfig(personal map[string]interface{}) error {
+	yamlData, err := yaml.Marshal(personal)
+	if err != nil {
+		return err
+	}
+
+	fileName := personalConfigFile()
+	if err := os.MkdirAll(filepath.Dir(fileName), 0700); err != nil {
+		return err
+	}
+	// the file contains API tokens
+	return os.WriteFile(fileName, yamlData, 0600)
+}
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/viper"
+)
+
+func TestTrustedConfigString(t *testing.T) {
+	tests := []struct {
+		name     string
+		project  string
+		personal string
+		key      string
+		want     string
+		warning  bool
+	}{
+		{"default of the project", "api:\n  insecure: false\n", "", "api.insecure", "", false},
+		{"project only", "api:\n  insecure: true\n", "", "api.insecure", "", true},
+		{"same as the personal config", "api:\n  insecure: true\n", "api:\n  insecure: true\n", "api.insecure", "true", false},
+		{"personal config wins", "api:\n  proxy: http://proxy.evil\n", "api:\n  proxy: http://proxy.corp\n", "api.proxy", "http://proxy.corp", true},
+		{"personal config only", "api:\n  url: http://kubero\n", "api:\n  tokenCommand: pass kubero\n", "api.tokenCommand", "pass kubero", false},
+		{"not set", "api:\n  url: http://kubero\n", "", "api.caFile", "", false},
+	}
+
+	defer viper.Reset()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			home := t.TempDir()
+			t.Setenv("HOME", home)
+			t.Setenv("KUBERO_PROFILE", "")
+			if tt.personal != "" {
+				os.MkdirAll(filepath.Join(home, ".kubero"), 0700)
+				os.WriteFile(personalConfigFile(), []byte(tt.personal), 0600)
+			}
+			projectFile := filepath.Join(home, "kubero.yaml")
+			os.WriteFile(projectFile, []byte(tt.project), 0600)
+
+			// like loadConfig, the personal config is merged into the one of the
+			// project
+			viper.Reset()
+			viper.SetConfigFile(projectFile)
+			viper.ReadInConfig()
+			viper.MergeConfigMap(loadPersonalConfig())
+			ignoredProjectSettings = map[string]bool{}
+
+			var value string
+			warning := captureStderr(t, func() {
+				value = trustedConfigString(tt.key)
+			})
+			if value != tt.want {
+				t.Errorf("value = %q, want %q", value, tt.want)
+			}
+			if (warning != "") != tt.warning {
+				t.Errorf("warning = %q, want a warning %v", warning, tt.warning)
+			}
+		})
+	}
+}
+
+// captureStderr returns what print writes to os.Stderr.
+func captureStderr(t *testing.T, print func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	stderr := os.Stderr
+	os.Stderr = w
+	defer func() { os.Stderr = stderr }()
+
+	print()
+	w.Close()
+	out, err := io.ReadAll(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return string(out)
+}
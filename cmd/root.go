@@ -2,9 +2,10 @@ package cmd
 
 import (
 	"bufio"
+	"context"
 	_ "embed"
-	"encoding/json"
 	"fmt"
+	"kubero/pkg/kuberoapi"
 	"os"
 	"reflect"
 	"strings"
@@ -13,11 +14,10 @@ import (
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing/cache"
 	"github.com/go-git/go-git/v5/storage/filesystem"
-	"github.com/go-resty/resty/v2"
 	"github.com/i582/cfmt/cmd/cfmt"
-	"github.com/olekukonko/tablewriter"
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
+	"golang.org/x/term"
 )
 
 var outputFormat string
@@ -76,11 +76,16 @@ func Execute() {
 
 func init() {
 	rootCmd.CompletionOptions.HiddenDefaultCmd = true
+	// set here, since InitClient refers to rootCmd itself
+	rootCmd.PersistentPreRun = func(cmd *cobra.Command, args []string) {
+		validateOutputFormat()
+		InitClient()
+	}
 	// Here you will define your flags and configuration settings.
 	// Cobra supports persistent flags, which, if defined here,
 	// will be global for your application.
 
-	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "table", "output format [table, json]")
+	rootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", "table", "Output format ["+outputFormats+"]")
 
 	// Cobra also supports local flags, which will only run
 	// when this action is called directly.
@@ -88,12 +93,10 @@ func init() {
 
 }
 
-func printCLI(table *tablewriter.Table, r *resty.Response) {
-	if outputFormat == "json" {
-		fmt.Println(r)
-	} else {
-		table.Render()
-	}
+// preRunWithoutClient replaces the PersistentPreRun of the root command for
+// commands which must work without a usable client of the active profile.
+func preRunWithoutClient(cmd *cobra.Command, args []string) {
+	validateOutputFormat()
 }
 
 // question, options/example, default
@@ -112,47 +115,62 @@ func promptLine(question string, options string, def string) string {
 	return text
 }
 
-type Repositories struct {
-	Github    bool `json:"github"`
-	Gitea     bool `json:"gitea"`
-	Gitlab    bool `json:"gitlab"`
-	Bitbucket bool `json:"bitbucket"`
-	Docker    bool `json:"docker"`
+// confirm asks a yes/no question, which --force answers with yes.
+func confirm(question string) bool {
+	if force {
+		return true
+	}
+	return promptLine(question, "[y,n]", "n") == "y"
 }
 
-var repoSimpleList []string
+// promptSecret asks for a value without echoing it, e.g. a password or token.
+func promptSecret(question string) string {
+	cfmt.Printf("\n  %s : ", question)
+	if !term.IsTerminal(int(os.Stdin.Fd())) {
+		reader := bufio.NewReader(os.Stdin)
+		text, _ := reader.ReadString('\n')
+		return strings.TrimSpace(text)
+	}
+	secret, _ := term.ReadPassword(int(os.Stdin.Fd()))
+	fmt.Println()
+	return strings.TrimSpace(string(secret))
+}
 
-func loadRepositories() {
+var repoSimpleList []string
 
-	rep, _ := client.Get("/api/cli/config/repositories")
+func loadRepositories(ctx context.Context) {
+	if !serverSupports(ctx, kuberoapi.CapabilityRepositories) {
+		printUnsupported("Listing the repository providers")
+		return
+	}
 
-	var availRep Repositories
-	json.Unmarshal(rep.Body(), &availRep)
+	availRep, err := client.GetRepositories(ctx)
+	if err != nil {
+		exitWithError(err)
+	}
 
-	t := reflect.TypeOf(availRep)
+	t := reflect.TypeOf(*availRep)
 
 	repoSimpleList = make([]string, t.NumField())
 	for i := range repoSimpleList {
-		if reflect.ValueOf(availRep).Field(i).Bool() {
+		if reflect.ValueOf(*availRep).Field(i).Bool() {
 			repoSimpleList[i] = t.Field(i).Name
 		}
 	}
 }
 
-type Contexts []struct {
-	Cluster string `json:"cluster"`
-	Name    string `json:"name"`
-	User    string `json:"user"`
-}
-
 var contextSimpleList []string
 
-func loadContexts() {
-
-	cont, _ := client.Get("/api/cli/config/k8s/context")
+func loadContexts(ctx context.Context) {
+	if !serverSupports(ctx, kuberoapi.CapabilityContexts) {
+		printUnsupported("Listing the cluster contexts")
+		return
+	}
 
-	var contexts Contexts
-	json.Unmarshal(cont.Body(), &contexts)
+	contexts, err := client.ListContexts(ctx)
+	if err != nil {
+		exitWithError(err)
+	}
 
 	for _, context := range contexts {
 		contextSimpleList = append(contextSimpleList, context.Name)
@@ -0,0 +1,91 @@
+package cmd
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+
+	"github.com/go-resty/resty/v2"
+	"golang.org/x/net/http/httpproxy"
+)
+
+// applyTransportConfig sets the TLS and proxy settings of the active profile
+// on an HTTP client:
+//
+//	api.caFile      PEM bundle trusted in addition to the system CAs
+//	api.clientCert  client certificate and key for mutual TLS, only used
+//	api.clientKey   if withClientCert is set
+//	api.insecure    skip the verification of server certificates
+//	api.proxy       proxy URL, overrides HTTPS_PROXY and HTTP_PROXY
+//
+// Without api.proxy the proxy is taken from the environment. NO_PROXY is
+// honored in both cases. The settings which let others read the traffic are
+// ignored in the kubero.yaml of the current directory.
+func applyTransportConfig(c *resty.Client, withClientCert bool) error {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: trustedConfigString("api.insecure") == "true",
+	}
+
+	if caFile := trustedConfigString("api.caFile"); caFile != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		pem, err := os.ReadFile(caFile)
+		if err != nil {
+			return fmt.Errorf("reading api.caFile: %w", err)
+		}
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("api.caFile %s contains no PEM certificates", caFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if clientCert := configString("api.clientCert"); withClientCert && clientCert != "" {
+		clientKey := configString("api.clientKey")
+		if clientKey == "" {
+			return errors.New("api.clientCert requires api.clientKey")
+		}
+		cert, err := tls.LoadX509KeyPair(clientCert, clientKey)
+		if err != nil {
+			return fmt.Errorf("loading the client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	c.SetTLSClientConfig(tlsConfig)
+
+	if proxy := trustedConfigString("api.proxy"); proxy != "" {
+		transport, ok := c.GetClient().Transport.(*http.Transport)
+		if !ok {
+			return errors.New("can't set a proxy on a custom transport")
+		}
+		proxyConfig := httpproxy.FromEnvironment()
+		proxyConfig.HTTPProxy = proxy
+		proxyConfig.HTTPSProxy = proxy
+		proxyFunc := proxyConfig.ProxyFunc()
+		transport.Proxy = func(req *http.Request) (*url.URL, error) {
+			return proxyFunc(req.URL)
+		}
+	}
+
+	return nil
+}
+
+// newInstallerClient creates an HTTP client for the cloud provider APIs and
+// downloads of the installer. It uses the CA, insecure and proxy settings of
+// the active profile, since they usually apply to the whole network.
+func newInstallerClient() *resty.Client {
+	installer := resty.New().SetHeader("User-Agent", userAgent())
+	if err := applyTransportConfig(installer, false); err != nil {
+		exitWithError(err)
+	}
+	if debugEnabled() {
+		enableDebug(installer)
+	}
+	return installer
+}
@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"kubero/pkg/kuberoapi"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/i582/cfmt/cmd/cfmt"
+	"github.com/olekukonko/tablewriter"
+	"github.com/spf13/cobra"
+)
+
+// the detected server capabilities are refreshed after this time
+const serverInfoTTL = 24 * time.Hour
+
+// versionCmd represents the version command
+var versionCmd = &cobra.Command{
+	Use:   "version",
+	Short: "Print the version of the CLI and the Kubero server",
+	Run: func(cmd *cobra.Command, args []string) {
+		info := versionInfo{
+			CLI: cliVersion(),
+			Go:  runtime.Version(),
+			OS:  runtime.GOOS + "/" + runtime.GOARCH,
+		}
+		if client.BaseURL() == "" {
+			printVersion(info)
+			return
+		}
+
+		// always ask the server, it may have been updated
+		server, err := detectServer(cmd.Context())
+		if err != nil {
+			printVersion(info)
+			exitWithError(err)
+		}
+		info.Server = server.URL
+		info.ServerVersion = server.Version
+		if info.ServerVersion == "" {
+			info.ServerVersion = "unknown"
+		}
+		for capability, supported := range server.Capabilities {
+			if !supported {
+				info.Unsupported = append(info.Unsupported, capability)
+			}
+		}
+		sort.Strings(info.Unsupported)
+		printVersion(info)
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(versionCmd)
+}
+
+type versionInfo struct {
+	CLI           string   `json:"cli"`
+	Go            string   `json:"go"`
+	OS            string   `json:"os"`
+	Server        string   `json:"server,omitempty"`
+	ServerVersion string   `json:"serverVersion,omitempty"`
+	Unsupported   []string `json:"unsupported,omitempty"`
+}
+
+func printVersion(info versionInfo) {
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetBorder(false)
+	table.SetAutoWrapText(false)
+	table.Append([]string{"CLI", info.CLI})
+	table.Append([]string{"Go", info.Go})
+	table.Append([]string{"OS", info.OS})
+	if info.Server != "" {
+		table.Append([]string{"Server", info.Server})
+		table.Append([]string{"Server version", info.ServerVersion})
+	}
+	if len(info.Unsupported) > 0 {
+		table.Append([]string{"Unsupported", strings.Join(info.Unsupported, ", ")})
+	}
+	printCLI(table, info)
+}
+
+// cliVersion returns the version embedded from cmd/VERSION at build time.
+func cliVersion() string {
+	v := strings.TrimSpace(version)
+	if v == "" {
+		return "dev"
+	}
+	return v
+}
+
+func userAgent() string {
+	return "kubero-cli/" + strings.TrimPrefix(cliVersion(), "v")
+}
+
+func serverInfoFile() string {
+	return filepath.Join(cacheDir(), "server.json")
+}
+
+// detectServer asks the server for its version and capabilities and caches
+// the result. A cache that can't be written only costs a new detection.
+func detectServer(ctx context.Context) (*kuberoapi.ServerInfo, error) {
+	info, err := client.DetectServer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	fileName := serverInfoFile()
+	if data, err := json.Marshal(info); err == nil && os.MkdirAll(filepath.Dir(fileName), 0700) == nil {
+		os.WriteFile(fileName, data, 0600)
+	}
+	return info, nil
+}
+
+// serverInfo returns the cached version and capabilities of the server, and
+// detects them if the cache is missing or outdated.
+func serverInfo(ctx context.Context) (*kuberoapi.ServerInfo, error) {
+	var info kuberoapi.ServerInfo
+	data, err := os.ReadFile(serverInfoFile())
+	if err == nil && !refreshCache && json.Unmarshal(data, &info) == nil &&
+		info.URL == client.BaseURL() && time.Since(info.DetectedAt) < serverInfoTTL {
+		return &info, nil
+	}
+	return detectServer(ctx)
+}
+
+// serverSupports reports whether the server has a capability. Commands use it
+// to fall back to a simpler behavior on older servers.
+func serverSupports(ctx context.Context, capability string) bool {
+	info, err := serverInfo(ctx)
+	if err != nil {
+		// the request of the command itself will tell
+		return true
+	}
+	return info.Supports(capability)
+}
+
+// requireCapability exits if the server lacks a capability the command needs.
+func requireCapability(ctx context.Context, capability string) {
+	if serverSupports(ctx, capability) {
+		return
+	}
+	info, _ := serverInfo(ctx)
+	serverVersion := info.Version
+	if serverVersion == "" {
+		serverVersion = "unknown"
+	}
+	exitWithError(&unsupportedError{capability: capability, server: info.URL, version: serverVersion})
+}
+
+type unsupportedError struct {
+	capability string
+	server     string
+	version    string
+}
+
+func (e *unsupportedError) Error() string {
+	return fmt.Sprintf("%s is not supported by the Kubero server at %s (version %s)", e.capability, e.server, e.version)
+}
+
+func isUnsupported(err error) bool {
+	var unsupportedErr *unsupportedError
+	return errors.As(err, &unsupportedErr) || kuberoapi.IsUnsupported(err)
+}
+
+// printUnsupported warns that a command falls back to a simpler behavior.
+func printUnsupported(what string) {
+	cfmt.Fprintln(os.Stderr, "{{⚠ "+what+" is not supported by this Kubero server}}::yellow")
+}
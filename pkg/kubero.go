@@ -13,7 +13,6 @@ import (
 func main() {
 
 	loadConfig()
-	cmd.InitClient()
 
 	cmd.Execute()
 }
@@ -28,13 +27,24 @@ func loadConfig() {
 	viper.AddConfigPath(".")      // TODO this should search for the git repo root
 	err := viper.ReadInConfig()
 
+	system := viper.New()
+	system.SetConfigName("kubero")       // name of config file (without extension)
+	system.SetConfigType("yaml")         // REQUIRED if the config file does not have the extension in the name
+	system.AddConfigPath("/etc/kubero/") // path to look for the config file in
+	errSystem := system.ReadInConfig()
+
+	// the personal config is read in addition to the system wide one, since it
+	// holds the profiles and credentials written by the CLI
 	personal := viper.New()
-	personal.SetConfigName("kubero")        // name of config file (without extension)
-	personal.SetConfigType("yaml")          // REQUIRED if the config file does not have the extension in the name
-	personal.AddConfigPath("/etc/kubero/")  // path to look for the config file in
-	personal.AddConfigPath("$HOME/.kubero") // call multiple times to add many search paths
+	personal.SetConfigName("kubero")
+	personal.SetConfigType("yaml")
+	personal.AddConfigPath("$HOME/.kubero")
 	errCred := personal.ReadInConfig()
+	if errCred != nil {
+		errCred = errSystem
+	}
 
+	viper.MergeConfigMap(system.AllSettings())
 	viper.MergeConfigMap(personal.AllSettings())
 	if err != nil && errCred != nil {
 
@@ -0,0 +1,388 @@
+package kuberoapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type App struct {
+	Addons   []interface{} `json:"addons"`
+	Affinity struct {
+	} `json:"affinity"`
+	Autodeploy  bool `json:"autodeploy"`
+	Autoscale   bool `json:"autoscale"`
+	Autoscaling struct {
+		Enabled bool `json:"enabled"`
+	} `json:"autoscaling"`
+	Branch             string        `json:"branch"`
+	Cronjobs           []interface{} `json:"cronjobs"`
+	Deploymentstrategy string        `json:"deploymentstrategy"`
+	Domain             string        `json:"domain"`
+	EnvVars            []EnvVar      `json:"envVars"`
+	FullnameOverride   string        `json:"fullnameOverride"`
+	Gitrepo            struct {
+		Admin         bool   `json:"admin"`
+		CloneURL      string `json:"clone_url"`
+		DefaultBranch string `json:"default_branch"`
+		Description   string `json:"description"`
+		Homepage      string `json:"homepage"`
+		ID            int    `json:"id"`
+		Language      string `json:"language"`
+		Name          string `json:"name"`
+		NodeID        string `json:"node_id"`
+		Owner         string `json:"owner"`
+		Private       bool   `json:"private"`
+		Push          bool   `json:"push"`
+		SSHURL        string `json:"ssh_url"`
+		Visibility    string `json:"visibility"`
+	} `json:"gitrepo"`
+	Image struct {
+		Build struct {
+			Command    string `json:"command"`
+			Repository string `json:"repository"`
+			Tag        string `json:"tag"`
+		} `json:"build"`
+		ContainerPort int `json:"containerPort"`
+		Fetch         struct {
+			Repository string `json:"repository"`
+			Tag        string `json:"tag"`
+		} `json:"fetch"`
+		PullPolicy string `json:"pullPolicy"`
+		Repository string `json:"repository"`
+		Run        struct {
+			Command    string `json:"command"`
+			Repository string `json:"repository"`
+			Tag        string `json:"tag"`
+		} `json:"run"`
+		Tag string `json:"tag"`
+	} `json:"image"`
+	ImagePullSecrets []interface{} `json:"imagePullSecrets"`
+	Ingress          struct {
+		Annotations struct {
+		} `json:"annotations"`
+		ClassName string `json:"className"`
+		Enabled   bool   `json:"enabled"`
+		Hosts     []struct {
+			Host  string `json:"host"`
+			Paths []struct {
+				Path     string `json:"path"`
+				PathType string `json:"pathType"`
+			} `json:"paths"`
+		} `json:"hosts"`
+		TLS []interface{} `json:"tls"`
+	} `json:"ingress"`
+	Name         string `json:"name"`
+	NameOverride string `json:"nameOverride"`
+	NodeSelector struct {
+	} `json:"nodeSelector"`
+	Phase          string `json:"phase"`
+	Pipeline       string `json:"pipeline"`
+	PodAnnotations struct {
+	} `json:"podAnnotations"`
+	PodSecurityContext struct {
+	} `json:"podSecurityContext"`
+	Podsize      string `json:"podsize"`
+	ReplicaCount int    `json:"replicaCount"`
+	Service      struct {
+		Port int    `json:"port"`
+		Type string `json:"type"`
+	} `json:"service"`
+	ServiceAccount struct {
+		Annotations struct {
+		} `json:"annotations"`
+		Create bool   `json:"create"`
+		Name   string `json:"name"`
+	} `json:"serviceAccount"`
+	Tolerations []interface{} `json:"tolerations"`
+	Web         struct {
+		Autoscaling struct {
+			MaxReplicas                       int `json:"maxReplicas"`
+			MinReplicas                       int `json:"minReplicas"`
+			TargetCPUUtilizationPercentage    int `json:"targetCPUUtilizationPercentage"`
+			TargetMemoryUtilizationPercentage int `json:"targetMemoryUtilizationPercentage"`
+		} `json:"autoscaling"`
+		ReplicaCount int `json:"replicaCount"`
+	} `json:"web"`
+	Worker struct {
+		Autoscaling struct {
+			MaxReplicas                       int `json:"maxReplicas"`
+			MinReplicas                       int `json:"minReplicas"`
+			TargetCPUUtilizationPercentage    int `json:"targetCPUUtilizationPercentage"`
+			TargetMemoryUtilizationPercentage int `json:"targetMemoryUtilizationPercentage"`
+		} `json:"autoscaling"`
+		ReplicaCount int `json:"replicaCount"`
+	} `json:"worker"`
+}
+
+// EnvVar is an environment variable of an app.
+type EnvVar struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// UnmarshalJSON also accepts "NAME=value" strings, which older versions of
+// the CLI sent.
+func (e *EnvVar) UnmarshalJSON(data []byte) error {
+	var s string
+	if json.Unmarshal(data, &s) == nil {
+		*e = ParseEnvVar(s)
+		return nil
+	}
+	type envVar EnvVar
+	return json.Unmarshal(data, (*envVar)(e))
+}
+
+// UnmarshalYAML also accepts "NAME=value" strings, which older versions of
+// the CLI wrote to app.<phase>.yaml.
+func (e *EnvVar) UnmarshalYAML(node *yaml.Node) error {
+	if node.Kind == yaml.ScalarNode {
+		*e = ParseEnvVar(node.Value)
+		return nil
+	}
+	type envVar EnvVar
+	return node.Decode((*envVar)(e))
+}
+
+// ParseEnvVar parses "NAME=value". The value may contain "=".
+func ParseEnvVar(s string) EnvVar {
+	name, value, _ := strings.Cut(s, "=")
+	return EnvVar{Name: name, Value: value}
+}
+
+// Deployment strategies of pipelines and apps.
+const (
+	// StrategyGit builds the images from a git repository.
+	StrategyGit = "git"
+	// StrategyDocker runs images built elsewhere.
+	StrategyDocker = "docker"
+)
+
+type CreateApp struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Metadata   struct {
+	} `json:"metadata"`
+	Spec AppSpec `json:"spec"`
+}
+
+type AppSpec struct {
+	Addons   []interface{} `json:"addons"`
+	Affinity struct {
+	} `json:"affinity"`
+	Autodeploy  bool `json:"autodeploy"`
+	Autoscale   bool `json:"autoscale"`
+	Autoscaling struct {
+		Enabled bool `json:"enabled"`
+	} `json:"autoscaling"`
+	Branch             string        `json:"branch"`
+	Buildpack          string        `json:"buildpack"`
+	Cronjobs           []interface{} `json:"cronjobs"`
+	Deploymentstrategy string        `json:"deploymentstrategy"`
+	Domain             string        `json:"domain"`
+	EnvVars            []EnvVar      `json:"envvars"`
+	FullnameOverride   string        `json:"fullnameOverride"`
+	Gitrepo            struct {
+		Admin         bool   `json:"admin"`
+		CloneURL      string `json:"clone_url"`
+		DefaultBranch string `json:"default_branch"`
+		Description   string `json:"description"`
+		Homepage      string `json:"homepage"`
+		ID            int    `json:"id"`
+		Language      string `json:"language"`
+		Name          string `json:"name"`
+		NodeID        string `json:"node_id"`
+		Owner         string `json:"owner"`
+		Private       bool   `json:"private"`
+		Push          bool   `json:"push"`
+		SSHURL        string `json:"ssh_url"`
+		Visibility    string `json:"visibility"`
+	} `json:"gitrepo"`
+	Image struct {
+		Fetch struct {
+			Repository string `json:"repository"`
+			Tag        string `json:"tag"`
+		} `json:"fetch"`
+		Build struct {
+			Command    string `json:"command"`
+			Repository string `json:"repository"`
+			Tag        string `json:"tag"`
+		} `json:"build"`
+		Run struct {
+			Command    string `json:"command"`
+			Repository string `json:"repository"`
+			Tag        string `json:"tag"`
+		} `json:"run"`
+		ContainerPort int    `json:"containerPort"`
+		PullPolicy    string `json:"pullPolicy"`
+		Repository    string `json:"repository"`
+		Tag           string `json:"tag"`
+	} `json:"image"`
+	ImagePullSecrets []interface{} `json:"imagePullSecrets"`
+	Ingress          struct {
+		Annotations struct {
+		} `json:"annotations"`
+		ClassName string `json:"className"`
+		Enabled   bool   `json:"enabled"`
+		Hosts     []struct {
+			Host  string `json:"host"`
+			Paths []struct {
+				Path     string `json:"path"`
+				PathType string `json:"pathType"`
+			} `json:"paths"`
+		} `json:"hosts"`
+		TLS []interface{} `json:"tls"`
+	} `json:"ingress"`
+	Name         string `json:"appname"`
+	NameOverride string `json:"nameOverride"`
+	NodeSelector struct {
+	} `json:"nodeSelector"`
+	Phase          string `json:"phase"`
+	Pipeline       string `json:"pipeline"`
+	PodAnnotations struct {
+	} `json:"podAnnotations"`
+	PodSecurityContext struct {
+	} `json:"podSecurityContext"`
+	Podsize      string `json:"podsize"`
+	ReplicaCount int    `json:"replicaCount"`
+	Service      struct {
+		Port int    `json:"port"`
+		Type string `json:"type"`
+	} `json:"service"`
+	ServiceAccount struct {
+		Annotations struct {
+		} `json:"annotations"`
+		Create bool   `json:"create"`
+		Name   string `json:"name"`
+	} `json:"serviceAccount"`
+	Tolerations []interface{} `json:"tolerations"`
+	Web         struct {
+		Autoscaling struct {
+			MaxReplicas                       int `json:"maxReplicas"`
+			MinReplicas                       int `json:"minReplicas"`
+			TargetCPUUtilizationPercentage    int `json:"targetCPUUtilizationPercentage"`
+			TargetMemoryUtilizationPercentage int `json:"targetMemoryUtilizationPercentage"`
+		} `json:"autoscaling"`
+		ReplicaCount int `json:"replicaCount"`
+	} `json:"web"`
+	Worker struct {
+		Autoscaling struct {
+			MaxReplicas                       int `json:"maxReplicas"`
+			MinReplicas                       int `json:"minReplicas"`
+			TargetCPUUtilizationPercentage    int `json:"targetCPUUtilizationPercentage"`
+			TargetMemoryUtilizationPercentage int `json:"targetMemoryUtilizationPercentage"`
+		} `json:"autoscaling"`
+		ReplicaCount int `json:"replicaCount"`
+	} `json:"worker"`
+
+	// raw is the spec as sent by the server, including the fields this
+	// struct doesn't know
+	raw map[string]interface{}
+}
+
+// UnmarshalJSON keeps the whole object, so the fields unknown to AppSpec are
+// sent back by UpdateApp.
+func (s *AppSpec) UnmarshalJSON(data []byte) error {
+	type appSpec AppSpec
+	if err := json.Unmarshal(data, (*appSpec)(s)); err != nil {
+		return err
+	}
+	s.raw = nil
+	return json.Unmarshal(data, &s.raw)
+}
+
+// requestBody returns the spec merged into the object it was decoded from.
+func (s *AppSpec) requestBody() (interface{}, error) {
+	if s.raw == nil {
+		return s, nil
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	var known map[string]interface{}
+	if err := json.Unmarshal(data, &known); err != nil {
+		return nil, err
+	}
+	return mergeObjects(s.raw, known), nil
+}
+
+// mergeObjects returns base with the values of overlay. Nested objects are
+// merged, so keys only known to base are kept; other values are replaced.
+func mergeObjects(base map[string]interface{}, overlay map[string]interface{}) map[string]interface{} {
+	merged := make(map[string]interface{}, len(base)+len(overlay))
+	for k, v := range base {
+		merged[k] = v
+	}
+	for k, v := range overlay {
+		baseObject, baseIsObject := merged[k].(map[string]interface{})
+		overlayObject, overlayIsObject := v.(map[string]interface{})
+		if baseIsObject && overlayIsObject {
+			merged[k] = mergeObjects(baseObject, overlayObject)
+		} else {
+			merged[k] = v
+		}
+	}
+	return merged
+}
+
+// ListApps returns the pipeline with the apps of all its phases.
+func (c *Client) ListApps(ctx context.Context, pipeline string) (*Pipeline, error) {
+	var apps Pipeline
+	if err := c.do(ctx, http.MethodGet, apiPath("pipelines", pipeline, "apps"), nil, &apps); err != nil {
+		return nil, err
+	}
+	return &apps, nil
+}
+
+// GetApp returns the app running in the given phase of a pipeline.
+func (c *Client) GetApp(ctx context.Context, pipeline string, phase string, app string) (*CreateApp, error) {
+	// the server may store the name as "name", not as "appname"
+	var a CreateApp
+	a.Spec.Name = app
+	a.Spec.Pipeline = pipeline
+	a.Spec.Phase = phase
+	if err := c.do(ctx, http.MethodGet, apiPath("pipelines", pipeline, phase, app), nil, &a); err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
+
+// CreateApp creates a new app in a pipeline phase and returns it as stored
+// by the server.
+func (c *Client) CreateApp(ctx context.Context, spec *AppSpec) (*AppSpec, error) {
+	var created AppSpec
+	if err := c.do(ctx, http.MethodPost, apiPath("apps"), spec, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// UpdateApp replaces the configuration of an existing app in the given phase
+// of a pipeline and returns it as stored by the server. Fields of a spec
+// returned by GetApp that AppSpec doesn't know are sent back unchanged.
+func (c *Client) UpdateApp(ctx context.Context, pipeline string, phase string, app string, spec *AppSpec) (*AppSpec, error) {
+	body, err := spec.requestBody()
+	if err != nil {
+		return nil, err
+	}
+	var updated AppSpec
+	if err := c.do(ctx, http.MethodPut, apiPath("pipelines", pipeline, phase, app), body, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// DeleteApp deletes an app from a pipeline phase.
+func (c *Client) DeleteApp(ctx context.Context, pipeline string, phase string, app string) error {
+	return c.do(ctx, http.MethodDelete, apiPath("pipelines", pipeline, phase, app), nil, nil)
+}
+
+// RestartApp triggers a rolling restart of all pods of an app. The server
+// expects a GET, which is not retried, so the app isn't restarted twice.
+func (c *Client) RestartApp(ctx context.Context, pipeline string, phase string, app string) error {
+	return c.do(WithoutRetry(ctx), http.MethodGet, apiPath("pipelines", pipeline, phase, app, "restart"), nil, nil)
+}
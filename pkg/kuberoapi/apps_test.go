@@ -0,0 +1,78 @@
+package kuberoapi
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestUpdateAppKeepsUnknownFields(t *testing.T) {
+	var sent map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`{"spec":{"name":"web","replicaCount":1,"sleep":"5m","web":{"replicaCount":1,"probes":{"path":"/health"}}}}`))
+		case http.MethodPut:
+			if r.URL.Path != "/api/cli/pipelines/shop/stage/web" {
+				t.Errorf("PUT %s", r.URL.Path)
+			}
+			body, _ := io.ReadAll(r.Body)
+			json.Unmarshal(body, &sent)
+			w.Write(body)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "token")
+	ctx := context.Background()
+	app, err := client.GetApp(ctx, "shop", "stage", "web")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if app.Spec.Name != "web" || app.Spec.Pipeline != "shop" || app.Spec.Phase != "stage" {
+		t.Errorf("name, pipeline, phase = %s, %s, %s", app.Spec.Name, app.Spec.Pipeline, app.Spec.Phase)
+	}
+
+	app.Spec.Web.ReplicaCount = 3
+	if _, err := client.UpdateApp(ctx, "shop", "stage", "web", &app.Spec); err != nil {
+		t.Fatal(err)
+	}
+	web, _ := sent["web"].(map[string]interface{})
+	if sent["sleep"] != "5m" || !reflect.DeepEqual(web["probes"], map[string]interface{}{"path": "/health"}) {
+		t.Errorf("unknown fields were dropped: %v", sent)
+	}
+	if web["replicaCount"] != 3.0 {
+		t.Errorf("web.replicaCount = %v, want 3", web["replicaCount"])
+	}
+}
+
+func TestMergeObjects(t *testing.T) {
+	tests := []struct {
+		name    string
+		base    string
+		overlay string
+		want    string
+	}{
+		{"keeps unknown keys", `{"a":1,"b":2}`, `{"a":3}`, `{"a":3,"b":2}`},
+		{"merges nested objects", `{"o":{"a":1,"b":2}}`, `{"o":{"a":3}}`, `{"o":{"a":3,"b":2}}`},
+		{"replaces lists", `{"l":[1,2]}`, `{"l":[3]}`, `{"l":[3]}`},
+		{"replaces an object by another value", `{"o":{"a":1}}`, `{"o":null}`, `{"o":null}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var base, overlay, want map[string]interface{}
+			json.Unmarshal([]byte(tt.base), &base)
+			json.Unmarshal([]byte(tt.overlay), &overlay)
+			json.Unmarshal([]byte(tt.want), &want)
+			if got := mergeObjects(base, overlay); !reflect.DeepEqual(got, want) {
+				t.Errorf("got %v, want %v", got, want)
+			}
+		})
+	}
+}
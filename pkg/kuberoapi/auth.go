@@ -0,0 +1,49 @@
+package kuberoapi
+
+import (
+	"context"
+	"net/http"
+)
+
+// User is the account the client is authenticated as.
+type User struct {
+	ID       int    `json:"id"`
+	Method   string `json:"method"`
+	Username string `json:"username"`
+}
+
+type loginRequest struct {
+	Username string `json:"username"`
+	Password string `json:"password"`
+}
+
+type loginResponse struct {
+	Token string `json:"token"`
+}
+
+// SetToken replaces the bearer token used for all further requests.
+func (c *Client) SetToken(token string) *Client {
+	c.tokenSource = nil
+	c.http.SetAuthToken(token)
+	return c
+}
+
+// Login exchanges the username and password of a Kubero UI user for its API
+// token. The token is not set on the client.
+func (c *Client) Login(ctx context.Context, username string, password string) (string, error) {
+	var resp loginResponse
+	credentials := loginRequest{Username: username, Password: password}
+	if err := c.do(ctx, http.MethodPost, apiPath("login"), &credentials, &resp); err != nil {
+		return "", err
+	}
+	return resp.Token, nil
+}
+
+// WhoAmI returns the user the token of the client belongs to.
+func (c *Client) WhoAmI(ctx context.Context) (*User, error) {
+	var user User
+	if err := c.do(ctx, http.MethodGet, apiPath("whoami"), nil, &user); err != nil {
+		return nil, err
+	}
+	return &user, nil
+}
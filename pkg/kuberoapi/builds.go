@@ -0,0 +1,87 @@
+package kuberoapi
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Status of a build.
+const (
+	BuildRunning   = "running"
+	BuildSucceeded = "succeeded"
+	BuildFailed    = "failed"
+)
+
+// Build is a run of the build pipeline of an app, which fetches the code,
+// builds an image and deploys it.
+type Build struct {
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	// Ref is the branch, tag or commit that was built, Commit the commit it
+	// resolved to.
+	Ref            string     `json:"ref"`
+	Commit         string     `json:"commit"`
+	Image          string     `json:"image"`
+	StartTime      time.Time  `json:"startTime"`
+	CompletionTime *time.Time `json:"completionTime,omitempty"`
+	// Steps are fetch, build and run, in this order.
+	Steps []BuildStep `json:"steps"`
+}
+
+// BuildStep is a step of a build. Steps not started yet have no StartTime.
+type BuildStep struct {
+	Name           string     `json:"name"`
+	Status         string     `json:"status"`
+	StartTime      time.Time  `json:"startTime"`
+	CompletionTime *time.Time `json:"completionTime,omitempty"`
+}
+
+// Finished reports whether the build is no longer running.
+func (b *Build) Finished() bool {
+	return b.Status == BuildSucceeded || b.Status == BuildFailed
+}
+
+// BuildRequest starts a build.
+type BuildRequest struct {
+	// Ref is the branch, tag or commit to build. Empty builds the branch of
+	// the app.
+	Ref string `json:"ref,omitempty"`
+}
+
+// ListBuilds returns the builds of an app, newest first.
+func (c *Client) ListBuilds(ctx context.Context, pipeline string, phase string, app string) ([]Build, error) {
+	var builds []Build
+	if err := c.do(ctx, http.MethodGet, apiPath("pipelines", pipeline, phase, app, "builds"), nil, &builds); err != nil {
+		return nil, err
+	}
+	return builds, nil
+}
+
+// TriggerBuild starts a build of an app and returns it.
+func (c *Client) TriggerBuild(ctx context.Context, pipeline string, phase string, app string, req *BuildRequest) (*Build, error) {
+	var build Build
+	if err := c.do(ctx, http.MethodPost, apiPath("pipelines", pipeline, phase, app, "builds"), req, &build); err != nil {
+		return nil, err
+	}
+	return &build, nil
+}
+
+// GetBuild returns a build of an app.
+func (c *Client) GetBuild(ctx context.Context, pipeline string, phase string, app string, name string) (*Build, error) {
+	var build Build
+	if err := c.do(ctx, http.MethodGet, apiPath("pipelines", pipeline, phase, app, "builds", name), nil, &build); err != nil {
+		return nil, err
+	}
+	return &build, nil
+}
+
+// GetBuildLogs returns the log lines of all steps of a build. The Container
+// of a line is the name of its step.
+func (c *Client) GetBuildLogs(ctx context.Context, pipeline string, phase string, app string, name string) ([]LogLine, error) {
+	var lines []LogLine
+	if err := c.do(ctx, http.MethodGet, apiPath("pipelines", pipeline, phase, app, "builds", name, "logs"), nil, &lines); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
@@ -0,0 +1,70 @@
+package kuberoapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// Cache stores the responses of the configuration endpoints (buildpacks,
+// pod sizes, addons, repositories and contexts), which rarely change.
+type Cache interface {
+	// Get returns the entry stored for key, if there is one.
+	Get(key string) (*CacheEntry, bool)
+	// Set stores an entry for key.
+	Set(key string, entry *CacheEntry)
+}
+
+// CacheEntry is a cached response body.
+type CacheEntry struct {
+	Body     []byte    `json:"body"`
+	ETag     string    `json:"etag,omitempty"`
+	StoredAt time.Time `json:"storedAt"`
+}
+
+// SetCache makes the client cache the responses of configuration endpoints.
+// Entries younger than ttl are used without asking the server, older ones
+// are revalidated with their ETag.
+func (c *Client) SetCache(cache Cache, ttl time.Duration) *Client {
+	c.cache = cache
+	c.cacheTTL = ttl
+	return c
+}
+
+// doCached GETs path like do, but answers from the cache if possible.
+func (c *Client) doCached(ctx context.Context, path string, result interface{}) error {
+	if c.cache == nil {
+		return c.do(ctx, http.MethodGet, path, nil, result)
+	}
+
+	key := c.http.BaseURL + path
+	entry, cached := c.cache.Get(key)
+	if !cached || time.Since(entry.StoredAt) >= c.cacheTTL {
+		header := map[string]string{}
+		if cached && entry.ETag != "" {
+			header["If-None-Match"] = entry.ETag
+		}
+		resp, err := c.executeWithHeader(ctx, http.MethodGet, path, nil, header)
+		if err != nil {
+			return err
+		}
+
+		if resp.StatusCode() != http.StatusNotModified {
+			entry = &CacheEntry{Body: resp.Body(), ETag: resp.Header().Get("ETag")}
+		} else if !cached {
+			return fmt.Errorf("kubero: GET %s: unexpected 304 Not Modified", path)
+		}
+		entry.StoredAt = time.Now()
+		c.cache.Set(key, entry)
+	}
+
+	if len(entry.Body) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(entry.Body, result); err != nil {
+		return fmt.Errorf("kubero: decoding response of GET %s: %w", path, err)
+	}
+	return nil
+}
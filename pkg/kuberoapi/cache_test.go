@@ -0,0 +1,99 @@
+package kuberoapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type memoryCache map[string]*CacheEntry
+
+func (c memoryCache) Get(key string) (*CacheEntry, bool) {
+	entry, ok := c[key]
+	return entry, ok
+}
+
+func (c memoryCache) Set(key string, entry *CacheEntry) {
+	c[key] = entry
+}
+
+func TestCache(t *testing.T) {
+	etag := `"v1"`
+	body := `[{"name":"small"}]`
+	var requests, notModified int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		if r.Header.Get("If-None-Match") == etag {
+			notModified++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	cache := memoryCache{}
+	client := NewClient(server.URL, "token").SetCache(cache, time.Hour)
+	key := server.URL + "/api/cli/config/podsize"
+
+	steps := []struct {
+		name        string
+		prepare     func()
+		requests    int
+		notModified int
+		want        string
+	}{
+		{"first request fills the cache", nil, 1, 0, "small"},
+		{"fresh entry is used without a request", nil, 1, 0, "small"},
+		{"outdated entry is revalidated", func() {
+			cache[key].StoredAt = time.Now().Add(-2 * time.Hour)
+		}, 2, 1, "small"},
+		{"revalidated entry is fresh again", nil, 2, 1, "small"},
+		{"changed resource replaces the entry", func() {
+			etag = `"v2"`
+			body = `[{"name":"large"}]`
+			cache[key].StoredAt = time.Time{}
+		}, 3, 1, "large"},
+	}
+
+	for _, step := range steps {
+		if step.prepare != nil {
+			step.prepare()
+		}
+		podsizes, err := client.ListPodsizes(context.Background())
+		if err != nil {
+			t.Fatalf("%s: %v", step.name, err)
+		}
+		if requests != step.requests || notModified != step.notModified {
+			t.Errorf("%s: %d requests, %d not modified, want %d, %d", step.name, requests, notModified, step.requests, step.notModified)
+		}
+		if len(podsizes) != 1 || podsizes[0].Name != step.want {
+			t.Errorf("%s: got %+v, want %s", step.name, podsizes, step.want)
+		}
+	}
+	if cache[key].ETag != `"v2"` {
+		t.Errorf("ETag = %s, want \"v2\"", cache[key].ETag)
+	}
+}
+
+func TestCacheKeepsErrorsOut(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"message":"down"}`))
+	}))
+	defer server.Close()
+
+	cache := memoryCache{}
+	client := NewClient(server.URL, "token").SetCache(cache, time.Hour)
+	if _, err := client.ListPodsizes(context.Background()); !IsServerError(err) {
+		t.Errorf("err = %v, want a server error", err)
+	}
+	if len(cache) != 0 {
+		t.Errorf("error response was cached")
+	}
+}
@@ -0,0 +1,181 @@
+// Package kuberoapi is a Go client for the CLI endpoints (/api/cli/...) of the
+// Kubero UI.
+package kuberoapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+)
+
+const apiPrefix = "/api/cli"
+
+// TokenSource returns the API token. It allows to look up the token only
+// when the first request is sent, e.g. from an external credential helper.
+type TokenSource func() (string, error)
+
+// Client talks to a single Kubero UI instance.
+type Client struct {
+	http  *resty.Client
+	retry RetryPolicy
+
+	cache    Cache
+	cacheTTL time.Duration
+
+	tokenSource TokenSource
+	tokenOnce   sync.Once
+	tokenErr    error
+}
+
+// NewClient creates a client for the Kubero UI reachable at baseURL, which
+// authenticates with the given bearer token.
+func NewClient(baseURL string, token string) *Client {
+	http := resty.New().
+		SetBaseURL(strings.TrimRight(baseURL, "/")).
+		SetAuthScheme("Bearer").
+		SetAuthToken(token).
+		SetHeader("Accept", "application/json").
+		SetHeader("Content-Type", "application/json")
+
+	return &Client{http: http, retry: DefaultRetryPolicy}
+}
+
+// SetUserAgent sets the User-Agent header sent with every request. Programs
+// using the client should name themselves and their version with it, the
+// client doesn't know either.
+func (c *Client) SetUserAgent(userAgent string) *Client {
+	c.http.SetHeader("User-Agent", userAgent)
+	return c
+}
+
+// SetTokenSource makes the client get its token from source, which is
+// called once before the first request. It replaces a token set before.
+func (c *Client) SetTokenSource(source TokenSource) *Client {
+	c.tokenSource = source
+	c.tokenOnce = sync.Once{}
+	c.tokenErr = nil
+	return c
+}
+
+// resolveToken sets the token from the token source, if there is one.
+func (c *Client) resolveToken() error {
+	if c.tokenSource == nil {
+		return nil
+	}
+	c.tokenOnce.Do(func() {
+		token, err := c.tokenSource()
+		if err != nil {
+			c.tokenErr = fmt.Errorf("kubero: getting the API token: %w", err)
+			return
+		}
+		c.http.SetAuthToken(token)
+	})
+	return c.tokenErr
+}
+
+// Resty returns the underlying HTTP client, e.g. to configure transport
+// settings. Changes apply to all further requests of this client.
+func (c *Client) Resty() *resty.Client {
+	return c.http
+}
+
+// BaseURL returns the URL of the Kubero UI this client talks to.
+func (c *Client) BaseURL() string {
+	return c.http.BaseURL
+}
+
+// apiPath builds an URL path below /api/cli from the given segments. Every
+// segment is escaped, so names containing slashes or spaces can't change the
+// addressed resource.
+func apiPath(segments ...string) string {
+	escaped := make([]string, len(segments))
+	for i, segment := range segments {
+		escaped[i] = url.PathEscape(segment)
+	}
+	return apiPrefix + "/" + strings.Join(escaped, "/")
+}
+
+// Raw sends a request to any endpoint of the Kubero UI. Paths without a
+// leading slash are relative to /api/cli. A []byte body is sent as is, other
+// bodies are encoded as JSON. Errors are the same as for the typed methods.
+func (c *Client) Raw(ctx context.Context, method string, path string, body interface{}) (*resty.Response, error) {
+	if !strings.HasPrefix(path, "/") {
+		path = apiPrefix + "/" + path
+	}
+	return c.execute(ctx, method, path, body)
+}
+
+// execute sends a request, retrying it according to the retry policy.
+// Responses with a non-2xx status are returned as *APIError, transport
+// failures as *NetworkError.
+func (c *Client) execute(ctx context.Context, method string, path string, body interface{}) (*resty.Response, error) {
+	return c.executeWithHeader(ctx, method, path, body, nil)
+}
+
+// executeWithHeader is execute with additional request headers.
+func (c *Client) executeWithHeader(ctx context.Context, method string, path string, body interface{}, header map[string]string) (*resty.Response, error) {
+	if err := c.resolveToken(); err != nil {
+		return nil, err
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.send(ctx, method, path, body, header)
+		if err == nil || attempt > c.retry.Retries || !c.retry.shouldRetry(ctx, method, err) {
+			return resp, err
+		}
+
+		wait := c.retry.backoff(attempt)
+		if c.retry.OnRetry != nil {
+			c.retry.OnRetry(attempt, wait, err)
+		}
+		select {
+		case <-ctx.Done():
+			return resp, err
+		case <-time.After(wait):
+		}
+	}
+}
+
+func (c *Client) send(ctx context.Context, method string, path string, body interface{}, header map[string]string) (*resty.Response, error) {
+	req := c.http.R().SetContext(ctx).SetHeaders(header)
+	if body != nil {
+		req.SetBody(body)
+	}
+
+	resp, err := req.Execute(method, path)
+	if err != nil {
+		return resp, &NetworkError{Method: method, URL: c.http.BaseURL + path, Err: err}
+	}
+	if strings.HasPrefix(path, apiPrefix+"/") && isUnknownRoute(resp) {
+		return resp, &UnsupportedError{Method: method, URL: c.http.BaseURL + path}
+	}
+	// 304 answers a conditional request of doCached
+	if !resp.IsSuccess() && resp.StatusCode() != http.StatusNotModified {
+		return resp, newAPIError(resp)
+	}
+	return resp, nil
+}
+
+// do sends a request and decodes a successful JSON response into result.
+// result and body may be nil.
+func (c *Client) do(ctx context.Context, method string, path string, body interface{}, result interface{}) error {
+	resp, err := c.execute(ctx, method, path, body)
+	if err != nil {
+		return err
+	}
+
+	if result == nil || len(resp.Body()) == 0 {
+		return nil
+	}
+	if err := json.Unmarshal(resp.Body(), result); err != nil {
+		return fmt.Errorf("kubero: decoding response of %s %s: %w", method, path, err)
+	}
+	return nil
+}
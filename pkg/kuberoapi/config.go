@@ -0,0 +1,129 @@
+package kuberoapi
+
+import (
+	"context"
+)
+
+type AddonsList []Addon
+
+type Addon struct {
+	ID      string `json:"id"`
+	Enabled bool   `json:"enabled"`
+	Version struct {
+		Latest    string `json:"latest"`
+		Installed string `json:"installed"`
+	} `json:"version,omitempty"`
+	Description string `json:"description,omitempty"`
+	Readme      string `json:"readme,omitempty"`
+	ArtifactURL string `json:"artifact_url"`
+	Kind        string `json:"kind"`
+	Install     string `json:"install"`
+	Beta        bool   `json:"beta"`
+}
+
+type Buildpacks []Buildpack
+
+type Buildpack struct {
+	Name     string `json:"name"`
+	Language string `json:"language"`
+	Fetch    struct {
+		Repository string `json:"repository"`
+		Tag        string `json:"tag"`
+	} `json:"fetch"`
+	Build struct {
+		Repository string `json:"repository"`
+		Tag        string `json:"tag"`
+		Command    string `json:"command"`
+	} `json:"build"`
+	Run struct {
+		Repository         string `json:"repository"`
+		Tag                string `json:"tag"`
+		ReadOnlyAppStorage bool   `json:"readOnlyAppStorage"`
+		SecurityContext    *struct {
+			AllowPrivilegeEscalation *bool `json:"allowPrivilegeEscalation"`
+			ReadOnlyRootFilesystem   *bool `json:"readOnlyRootFilesystem"`
+		} `json:"securityContext"`
+		Command string `json:"command"`
+	} `json:"run,omitempty"`
+}
+
+type PodsizeList []Podsize
+
+type Podsize struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Default     bool   `json:"default,omitempty"`
+	Resources   struct {
+		Requests struct {
+			Memory string `json:"memory"`
+			CPU    string `json:"cpu"`
+		} `json:"requests"`
+		Limits struct {
+			Memory string `json:"memory"`
+			CPU    string `json:"cpu"`
+		} `json:"limits,omitempty"`
+	} `json:"resources,omitempty"`
+	Active bool `json:"active,omitempty"`
+}
+
+type Repositories struct {
+	Github    bool `json:"github"`
+	Gitea     bool `json:"gitea"`
+	Gitlab    bool `json:"gitlab"`
+	Bitbucket bool `json:"bitbucket"`
+	Docker    bool `json:"docker"`
+}
+
+type Contexts []Context
+
+type Context struct {
+	Cluster string `json:"cluster"`
+	Name    string `json:"name"`
+	User    string `json:"user"`
+}
+
+// ListAddons returns the addons which can be added to an app.
+func (c *Client) ListAddons(ctx context.Context) (AddonsList, error) {
+	var addons AddonsList
+	if err := c.doCached(ctx, apiPath("addons"), &addons); err != nil {
+		return nil, err
+	}
+	return addons, nil
+}
+
+// ListBuildpacks returns the buildpacks available for pipelines.
+func (c *Client) ListBuildpacks(ctx context.Context) (Buildpacks, error) {
+	var buildpacks Buildpacks
+	if err := c.doCached(ctx, apiPath("config", "buildpacks"), &buildpacks); err != nil {
+		return nil, err
+	}
+	return buildpacks, nil
+}
+
+// ListPodsizes returns the pod sizes available for apps.
+func (c *Client) ListPodsizes(ctx context.Context) (PodsizeList, error) {
+	var podsizes PodsizeList
+	if err := c.doCached(ctx, apiPath("config", "podsize"), &podsizes); err != nil {
+		return nil, err
+	}
+	return podsizes, nil
+}
+
+// GetRepositories returns which git repository providers are configured.
+func (c *Client) GetRepositories(ctx context.Context) (*Repositories, error) {
+	var repositories Repositories
+	if err := c.doCached(ctx, apiPath("config", "repositories"), &repositories); err != nil {
+		return nil, err
+	}
+	return &repositories, nil
+}
+
+// ListContexts returns the kubernetes contexts pipeline phases can be
+// deployed to.
+func (c *Client) ListContexts(ctx context.Context) (Contexts, error) {
+	var contexts Contexts
+	if err := c.doCached(ctx, apiPath("config", "k8s", "context"), &contexts); err != nil {
+		return nil, err
+	}
+	return contexts, nil
+}
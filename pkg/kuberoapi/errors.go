@@ -0,0 +1,149 @@
+package kuberoapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/go-resty/resty/v2"
+)
+
+// APIError is returned when the Kubero UI answers with a non-2xx status.
+type APIError struct {
+	StatusCode int
+	Method     string
+	URL        string
+	// Message is the error message sent by the server, if there was one.
+	Message string
+	Body    []byte
+}
+
+func newAPIError(resp *resty.Response) *APIError {
+	return &APIError{
+		StatusCode: resp.StatusCode(),
+		Method:     resp.Request.Method,
+		URL:        resp.Request.URL,
+		Message:    parseErrorMessage(resp.Body()),
+		Body:       resp.Body(),
+	}
+}
+
+func (e *APIError) Error() string {
+	message := e.Message
+	if message == "" {
+		message = http.StatusText(e.StatusCode)
+	}
+	return fmt.Sprintf("kubero: %s %s: %d %s", e.Method, e.URL, e.StatusCode, message)
+}
+
+// parseErrorMessage extracts the message from an error payload. The Kubero UI
+// answers with JSON like {"message": "..."} or {"error": "..."}, with plain
+// text, or with an HTML page for unknown routes, which is ignored.
+func parseErrorMessage(body []byte) string {
+	var payload map[string]interface{}
+	if json.Unmarshal(body, &payload) == nil {
+		for _, key := range []string{"message", "error", "msg"} {
+			switch v := payload[key].(type) {
+			case string:
+				return v
+			case []interface{}:
+				messages := make([]string, 0, len(v))
+				for _, m := range v {
+					messages = append(messages, fmt.Sprint(m))
+				}
+				return strings.Join(messages, ", ")
+			}
+		}
+		return ""
+	}
+
+	text := strings.TrimSpace(string(body))
+	if strings.HasPrefix(text, "<") {
+		return ""
+	}
+	if i := strings.IndexByte(text, '\n'); i >= 0 {
+		text = text[:i]
+	}
+	return text
+}
+
+// NetworkError is returned when the Kubero UI could not be reached or the
+// connection broke before a response was received.
+type NetworkError struct {
+	Method string
+	URL    string
+	Err    error
+}
+
+func (e *NetworkError) Error() string {
+	return fmt.Sprintf("kubero: %s %s: %v", e.Method, e.URL, e.Err)
+}
+
+func (e *NetworkError) Unwrap() error {
+	return e.Err
+}
+
+// IsNotFound reports whether err is an APIError with status 404.
+func IsNotFound(err error) bool {
+	return hasStatus(err, http.StatusNotFound)
+}
+
+// IsUnauthorized reports whether err is an APIError with status 401 or 403.
+func IsUnauthorized(err error) bool {
+	return hasStatus(err, http.StatusUnauthorized) || hasStatus(err, http.StatusForbidden)
+}
+
+// IsValidation reports whether err is an APIError caused by a request the
+// server rejected as invalid (400, 409 or 422).
+func IsValidation(err error) bool {
+	return hasStatus(err, http.StatusBadRequest) ||
+		hasStatus(err, http.StatusConflict) ||
+		hasStatus(err, http.StatusUnprocessableEntity)
+}
+
+// IsServerError reports whether err is an APIError with a 5xx status.
+func IsServerError(err error) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode >= 500
+}
+
+// UnsupportedError is returned when the Kubero UI does not know an endpoint,
+// usually because it is older than the client. Unknown routes are answered
+// with the HTML page of the UI instead of JSON.
+type UnsupportedError struct {
+	Method string
+	URL    string
+}
+
+func (e *UnsupportedError) Error() string {
+	return fmt.Sprintf("kubero: %s %s: endpoint not supported by the server", e.Method, e.URL)
+}
+
+// isUnknownRoute reports whether resp is the HTML page the Kubero UI serves
+// for routes it doesn't know.
+func isUnknownRoute(resp *resty.Response) bool {
+	if resp.StatusCode() != http.StatusNotFound && !resp.IsSuccess() {
+		return false
+	}
+	return strings.HasPrefix(resp.Header().Get("Content-Type"), "text/html") ||
+		strings.HasPrefix(strings.TrimSpace(string(resp.Body())), "<")
+}
+
+// IsUnsupported reports whether err is an UnsupportedError.
+func IsUnsupported(err error) bool {
+	var unsupportedErr *UnsupportedError
+	return errors.As(err, &unsupportedErr)
+}
+
+// IsNetworkError reports whether err is a NetworkError.
+func IsNetworkError(err error) bool {
+	var netErr *NetworkError
+	return errors.As(err, &netErr)
+}
+
+func hasStatus(err error, status int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == status
+}
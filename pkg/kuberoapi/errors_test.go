@@ -0,0 +1,93 @@
+package kuberoapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestErrorClassification(t *testing.T) {
+	tests := []struct {
+		name        string
+		status      int
+		contentType string
+		body        string
+		check       func(error) bool
+		message     string
+	}{
+		{"json 404", 404, "application/json", `{"message":"app not found"}`, IsNotFound, "app not found"},
+		{"401", 401, "application/json", `{"error":"invalid token"}`, IsUnauthorized, "invalid token"},
+		{"403", 403, "application/json", `{}`, IsUnauthorized, ""},
+		{"422 with several messages", 422, "application/json", `{"message":["name missing","phase missing"]}`, IsValidation, "name missing, phase missing"},
+		{"409", 409, "application/json", `{"msg":"exists"}`, IsValidation, "exists"},
+		{"500 plain text", 500, "text/plain", "boom\n    at handler", IsServerError, "boom"},
+		{"html 404", 404, "text/html", "<!doctype html><html></html>", IsUnsupported, ""},
+		{"html 200 of the UI", 200, "text/html; charset=utf-8", "<!doctype html><html></html>", IsUnsupported, ""},
+		{"html without content type", 404, "", "  <html></html>", IsUnsupported, ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.contentType != "" {
+					w.Header().Set("Content-Type", tt.contentType)
+				}
+				w.WriteHeader(tt.status)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "token")
+			_, err := client.ListPipelines(context.Background())
+			if err == nil {
+				t.Fatal("no error")
+			}
+			if !tt.check(err) {
+				t.Errorf("unexpected classification of %T: %v", err, err)
+			}
+
+			var apiErr *APIError
+			if errors.As(err, &apiErr) {
+				if apiErr.StatusCode != tt.status || apiErr.Message != tt.message {
+					t.Errorf("got %d %q, want %d %q", apiErr.StatusCode, apiErr.Message, tt.status, tt.message)
+				}
+			} else if tt.message != "" {
+				t.Errorf("got %T, want an APIError", err)
+			}
+		})
+	}
+}
+
+func TestHTMLOutsideTheAPIIsNoUnsupportedError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte("<html></html>"))
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL, "token")
+	if _, err := client.Raw(context.Background(), http.MethodGet, "/login", nil); err != nil {
+		t.Errorf("err = %v, want none", err)
+	}
+}
+
+func TestNetworkError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	client := NewClient(server.URL, "token").SetRetryPolicy(RetryPolicy{})
+	_, err := client.ListPipelines(context.Background())
+
+	var netErr *NetworkError
+	if !errors.As(err, &netErr) {
+		t.Fatalf("err = %v, want a NetworkError", err)
+	}
+	if netErr.Method != http.MethodGet || netErr.URL != server.URL+"/api/cli/pipelines" || netErr.Err == nil {
+		t.Errorf("unexpected NetworkError %+v", netErr)
+	}
+	if IsNotFound(err) || IsServerError(err) || IsUnsupported(err) {
+		t.Errorf("NetworkError classified as API error")
+	}
+}
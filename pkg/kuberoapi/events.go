@@ -0,0 +1,36 @@
+package kuberoapi
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// Event is a Kubernetes event, e.g. a failed image pull or a killed pod.
+type Event struct {
+	Type           string    `json:"type"`
+	Reason         string    `json:"reason"`
+	Message        string    `json:"message"`
+	Count          int       `json:"count"`
+	LastTimestamp  time.Time `json:"lastTimestamp"`
+	InvolvedObject struct {
+		Kind string `json:"kind"`
+		Name string `json:"name"`
+	} `json:"involvedObject"`
+}
+
+// Namespace returns the Kubernetes namespace of a pipeline phase.
+func Namespace(pipeline string, phase string) string {
+	return pipeline + "-" + phase
+}
+
+// ListEvents returns the recent events of a namespace.
+func (c *Client) ListEvents(ctx context.Context, namespace string) ([]Event, error) {
+	var events []Event
+	path := apiPath("events") + "?namespace=" + url.QueryEscape(namespace)
+	if err := c.do(ctx, http.MethodGet, path, nil, &events); err != nil {
+		return nil, err
+	}
+	return events, nil
+}
@@ -0,0 +1,42 @@
+package kuberoapi
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Containers of an app pod whose logs can be read.
+const (
+	ContainerWeb    = "web"
+	ContainerWorker = "worker"
+	ContainerBuild  = "build"
+)
+
+// LogLine is a line written by a container of an app.
+type LogLine struct {
+	ID        string `json:"id"`
+	Time      int64  `json:"time"`
+	Pipeline  string `json:"pipeline"`
+	Phase     string `json:"phase"`
+	App       string `json:"app"`
+	Pod       string `json:"pod"`
+	PodID     string `json:"podID"`
+	Container string `json:"container"`
+	Log       string `json:"log"`
+}
+
+// Timestamp returns the time the line was written.
+func (l *LogLine) Timestamp() time.Time {
+	return time.UnixMilli(l.Time)
+}
+
+// GetLogs returns the recent log lines of a container of all pods of an app,
+// oldest first.
+func (c *Client) GetLogs(ctx context.Context, pipeline string, phase string, app string, container string) ([]LogLine, error) {
+	var lines []LogLine
+	if err := c.do(ctx, http.MethodGet, apiPath("logs", pipeline, phase, app, container, "history"), nil, &lines); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
@@ -0,0 +1,227 @@
+package kuberoapi
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+type Pipeline struct {
+	Buildpack struct {
+		Build struct {
+			Command    string `json:"command"`
+			Repository string `json:"repository"`
+			Tag        string `json:"tag"`
+		} `json:"build"`
+		Fetch struct {
+			Repository string `json:"repository"`
+			Tag        string `json:"tag"`
+		} `json:"fetch"`
+		Language string `json:"language"`
+		Name     string `json:"name"`
+		Run      struct {
+			Command    string `json:"command"`
+			Repository string `json:"repository"`
+			Tag        string `json:"tag"`
+		} `json:"run"`
+	} `json:"buildpack"`
+	Deploymentstrategy string `json:"deploymentstrategy"`
+	Dockerimage        string `json:"dockerimage"`
+	// ImagePullSecret is not known to the server. The CLI keeps it in
+	// pipeline.yaml as default for the apps of a docker pipeline.
+	ImagePullSecret string `json:"-"`
+	Git             struct {
+		Keys struct {
+			CreatedAt time.Time `json:"created_at"`
+			ID        int       `json:"id"`
+			Priv      string    `json:"priv"`
+			Pub       string    `json:"pub"`
+			ReadOnly  bool      `json:"read_only"`
+			Title     string    `json:"title"`
+			URL       string    `json:"url"`
+			Verified  bool      `json:"verified"`
+		} `json:"keys"`
+		Repository struct {
+			Admin         bool   `json:"admin"`
+			CloneURL      string `json:"clone_url"`
+			DefaultBranch string `json:"default_branch"`
+			Description   string `json:"description"`
+			Homepage      string `json:"homepage"`
+			ID            int    `json:"id"`
+			Language      string `json:"language"`
+			Name          string `json:"name"`
+			NodeID        string `json:"node_id"`
+			Owner         string `json:"owner"`
+			Private       bool   `json:"private"`
+			Push          bool   `json:"push"`
+			SSHURL        string `json:"ssh_url"`
+			Visibility    string `json:"visibility"`
+		} `json:"repository"`
+		Webhook struct {
+			Active    bool      `json:"active"`
+			CreatedAt time.Time `json:"created_at"`
+			Events    []string  `json:"events"`
+			ID        int       `json:"id"`
+			Insecure  string    `json:"insecure"`
+			URL       string    `json:"url"`
+		} `json:"webhook"`
+		Webhooks struct {
+		} `json:"webhooks"`
+	} `json:"git"`
+	Name   string `json:"name"`
+	Phases []struct {
+		Context string `json:"context"`
+		Enabled bool   `json:"enabled"`
+		Name    string `json:"name"`
+		Apps    []App  `json:"apps"`
+	} `json:"phases"`
+	Reviewapps bool `json:"reviewapps"`
+}
+type PipelinesList struct {
+	Items []Pipeline `json:"items"`
+}
+
+type CreatePipeline struct {
+	APIVersion string       `json:"apiVersion"`
+	Kind       string       `json:"kind"`
+	Spec       PipelineSpec `json:"spec"`
+}
+
+type CreatePipelines struct {
+	PipelineName       string  `json:"pipelineName"`
+	RepoProvider       string  `json:"repoprovider"`
+	RepositoryURL      string  `json:"repositoryURL"`
+	Phases             []Phase `json:"phases"`
+	Reviewapps         bool    `json:"reviewapps"`
+	Dockerimage        string  `json:"dockerimage"`
+	Deploymentstrategy string  `json:"deploymentstrategy"`
+	Buildpack          string  `json:"buildpack"`
+}
+
+type Phase struct {
+	Name    string `json:"name"`
+	Enabled bool   `json:"enabled"`
+	Context string `json:"context"`
+}
+
+type PipelineSpec struct {
+	Buildpack struct {
+		Build struct {
+			Command    string `json:"command"`
+			Repository string `json:"repository"`
+			Tag        string `json:"tag"`
+		} `json:"build"`
+		Fetch struct {
+			Repository string `json:"repository"`
+			Tag        string `json:"tag"`
+		} `json:"fetch"`
+		Language string `json:"language"`
+		Name     string `json:"name"`
+		Run      struct {
+			Command    string `json:"command"`
+			Repository string `json:"repository"`
+			Tag        string `json:"tag"`
+		} `json:"run"`
+	} `json:"buildpack"`
+	Deploymentstrategy string `json:"deploymentstrategy"`
+	Dockerimage        string `json:"dockerimage"`
+	// ImagePullSecret is not known to the server. The CLI keeps it in
+	// pipeline.yaml as default for the apps of a docker pipeline.
+	ImagePullSecret string `json:"-"`
+	Git             struct {
+		Keys struct {
+			CreatedAt time.Time `json:"created_at"`
+			ID        int       `json:"id"`
+			//Priv      string    `json:"priv"`
+			//Pub       string    `json:"pub"`
+			ReadOnly bool   `json:"read_only"`
+			Title    string `json:"title"`
+			URL      string `json:"url"`
+			Verified bool   `json:"verified"`
+		} `json:"keys"`
+		Repository struct {
+			Provider      string `json:"provider"`
+			Admin         bool   `json:"admin"`
+			CloneURL      string `json:"clone_url"`
+			DefaultBranch string `json:"default_branch"`
+			Description   string `json:"description"`
+			Homepage      string `json:"homepage"`
+			ID            int    `json:"id"`
+			Language      string `json:"language"`
+			Name          string `json:"name"`
+			NodeID        string `json:"node_id"`
+			Owner         string `json:"owner"`
+			Private       bool   `json:"private"`
+			Push          bool   `json:"push"`
+			SSHURL        string `json:"ssh_url"`
+			Visibility    string `json:"visibility"`
+		} `json:"repository"`
+		Webhook struct {
+			Active    bool      `json:"active"`
+			CreatedAt time.Time `json:"created_at"`
+			Events    []string  `json:"events"`
+			ID        int       `json:"id"`
+			Insecure  string    `json:"insecure"`
+			URL       string    `json:"url"`
+		} `json:"webhook"`
+		Webhooks struct {
+		} `json:"webhooks"`
+	} `json:"git"`
+	Name       string  `json:"pipelineName"`
+	Phases     []Phase `json:"phases"`
+	Reviewapps bool    `json:"reviewapps"`
+}
+
+// ListPipelines returns all pipelines.
+func (c *Client) ListPipelines(ctx context.Context) (*PipelinesList, error) {
+	var pipelines PipelinesList
+	if err := c.do(ctx, http.MethodGet, apiPath("pipelines"), nil, &pipelines); err != nil {
+		return nil, err
+	}
+	return &pipelines, nil
+}
+
+// GetPipeline returns a single pipeline.
+func (c *Client) GetPipeline(ctx context.Context, name string) (*Pipeline, error) {
+	var pipeline Pipeline
+	if err := c.do(ctx, http.MethodGet, apiPath("pipelines", name), nil, &pipeline); err != nil {
+		return nil, err
+	}
+	return &pipeline, nil
+}
+
+// GetPipelineSpec returns a single pipeline in the form accepted by
+// CreatePipeline.
+func (c *Client) GetPipelineSpec(ctx context.Context, name string) (*PipelineSpec, error) {
+	// the server stores the name as "name", not as "pipelineName"
+	spec := PipelineSpec{Name: name}
+	if err := c.do(ctx, http.MethodGet, apiPath("pipelines", name), nil, &spec); err != nil {
+		return nil, err
+	}
+	return &spec, nil
+}
+
+// CreatePipeline creates a new pipeline and returns it as stored by the
+// server.
+func (c *Client) CreatePipeline(ctx context.Context, spec *PipelineSpec) (*PipelineSpec, error) {
+	var created PipelineSpec
+	if err := c.do(ctx, http.MethodPost, apiPath("pipelines"), spec, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// UpdatePipeline replaces the configuration of an existing pipeline and
+// returns it as stored by the server.
+func (c *Client) UpdatePipeline(ctx context.Context, spec *PipelineSpec) (*PipelineSpec, error) {
+	var updated PipelineSpec
+	if err := c.do(ctx, http.MethodPut, apiPath("pipelines", spec.Name), spec, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// DeletePipeline deletes a pipeline including all its apps.
+func (c *Client) DeletePipeline(ctx context.Context, name string) error {
+	return c.do(ctx, http.MethodDelete, apiPath("pipelines", name), nil, nil)
+}
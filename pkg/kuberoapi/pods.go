@@ -0,0 +1,46 @@
+package kuberoapi
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Pod is a running instance of an app.
+type Pod struct {
+	Name       string         `json:"name"`
+	Phase      string         `json:"phase"`
+	StartTime  time.Time      `json:"startTime"`
+	Containers []PodContainer `json:"containers"`
+}
+
+// PodContainer is a container of a pod.
+type PodContainer struct {
+	Name         string `json:"name"`
+	Image        string `json:"image"`
+	Ready        bool   `json:"ready"`
+	Started      bool   `json:"started"`
+	RestartCount int    `json:"restartCount"`
+}
+
+// Ready reports whether the pod runs and all its containers are ready.
+func (p *Pod) Ready() bool {
+	if p.Phase != "Running" || len(p.Containers) == 0 {
+		return false
+	}
+	for _, c := range p.Containers {
+		if !c.Ready {
+			return false
+		}
+	}
+	return true
+}
+
+// ListPods returns the pods of an app.
+func (c *Client) ListPods(ctx context.Context, pipeline string, phase string, app string) ([]Pod, error) {
+	var pods []Pod
+	if err := c.do(ctx, http.MethodGet, apiPath("pipelines", pipeline, phase, app, "pods"), nil, &pods); err != nil {
+		return nil, err
+	}
+	return pods, nil
+}
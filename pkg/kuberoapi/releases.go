@@ -0,0 +1,28 @@
+package kuberoapi
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Release is a deployment of an image to an app.
+type Release struct {
+	Name   string `json:"name"`
+	Image  string `json:"image"`
+	Commit string `json:"commit"`
+	// TriggeredBy is the user or webhook that started the deployment.
+	TriggeredBy string `json:"triggeredBy"`
+	// Status is the status of the build of the image, see Build.
+	Status     string    `json:"status"`
+	DeployedAt time.Time `json:"deployedAt"`
+}
+
+// ListReleases returns the past deployments of an app.
+func (c *Client) ListReleases(ctx context.Context, pipeline string, phase string, app string) ([]Release, error) {
+	var releases []Release
+	if err := c.do(ctx, http.MethodGet, apiPath("pipelines", pipeline, phase, app, "releases"), nil, &releases); err != nil {
+		return nil, err
+	}
+	return releases, nil
+}
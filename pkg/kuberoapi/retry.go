@@ -0,0 +1,94 @@
+package kuberoapi
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// RetryPolicy controls how failed requests are retried. Only idempotent
+// requests (GET, HEAD, DELETE) are retried, and only if the server could not
+// be reached or answered 429, 502, 503 or 504, and not if the context was
+// created by WithoutRetry.
+type RetryPolicy struct {
+	// Retries is the number of retries after the first attempt.
+	Retries int
+	// WaitTime is the wait before the first retry. It doubles with every
+	// further retry up to MaxWaitTime, with random jitter of up to 50%.
+	WaitTime    time.Duration
+	MaxWaitTime time.Duration
+	// OnRetry is called before waiting for a retry, if it is set. attempt
+	// is the number of the upcoming retry, starting at 1.
+	OnRetry func(attempt int, wait time.Duration, err error)
+}
+
+// DefaultRetryPolicy is used by clients created with NewClient.
+var DefaultRetryPolicy = RetryPolicy{
+	Retries:     3,
+	WaitTime:    500 * time.Millisecond,
+	MaxWaitTime: 10 * time.Second,
+}
+
+// SetRetryPolicy replaces the retry policy of the client.
+func (c *Client) SetRetryPolicy(policy RetryPolicy) *Client {
+	c.retry = policy
+	return c
+}
+
+// SetTimeout sets the timeout of a single request attempt. Zero means no
+// timeout.
+func (c *Client) SetTimeout(timeout time.Duration) *Client {
+	c.http.SetTimeout(timeout)
+	return c
+}
+
+type noRetryKey struct{}
+
+// WithoutRetry returns a context whose requests are sent only once, e.g. for
+// GET requests with side effects.
+func WithoutRetry(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noRetryKey{}, true)
+}
+
+func (p RetryPolicy) shouldRetry(ctx context.Context, method string, err error) bool {
+	if noRetry, _ := ctx.Value(noRetryKey{}).(bool); noRetry {
+		return false
+	}
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodDelete:
+	default:
+		return false
+	}
+
+	// the caller gave up, retrying can't help
+	if ctx.Err() != nil {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.StatusCode {
+		case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+			return true
+		}
+		return false
+	}
+	return IsNetworkError(err)
+}
+
+// backoff returns the wait before the given retry.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	wait := p.WaitTime
+	for i := 1; i < attempt && (p.MaxWaitTime <= 0 || wait < p.MaxWaitTime); i++ {
+		wait *= 2
+	}
+	if p.MaxWaitTime > 0 && wait > p.MaxWaitTime {
+		wait = p.MaxWaitTime
+	}
+	if wait <= 0 {
+		return 0
+	}
+	return wait/2 + time.Duration(rand.Int63n(int64(wait/2)+1))
+}
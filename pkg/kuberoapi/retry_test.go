@@ -0,0 +1,111 @@
+package kuberoapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var testRetryPolicy = RetryPolicy{
+	Retries:     3,
+	WaitTime:    time.Millisecond,
+	MaxWaitTime: 2 * time.Millisecond,
+}
+
+func TestRetry(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		noRetry  bool
+		statuses []int
+		attempts int32
+		wantErr  bool
+	}{
+		{"GET succeeds after 503s", http.MethodGet, false, []int{503, 503, 200}, 3, false},
+		{"GET gives up after the retries", http.MethodGet, false, []int{429, 502, 503, 504, 200}, 4, true},
+		{"GET 404 is not retried", http.MethodGet, false, []int{404, 200}, 1, true},
+		{"GET 500 is not retried", http.MethodGet, false, []int{500, 200}, 1, true},
+		{"DELETE is retried", http.MethodDelete, false, []int{503, 200}, 2, false},
+		{"POST is not retried", http.MethodPost, false, []int{503, 200}, 1, true},
+		{"PUT is not retried", http.MethodPut, false, []int{503, 200}, 1, true},
+		{"GET without retry", http.MethodGet, true, []int{503, 200}, 1, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := atomic.AddInt32(&attempts, 1)
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(tt.statuses[n-1])
+				w.Write([]byte(`{}`))
+			}))
+			defer server.Close()
+
+			client := NewClient(server.URL, "token").SetRetryPolicy(testRetryPolicy)
+			ctx := context.Background()
+			if tt.noRetry {
+				ctx = WithoutRetry(ctx)
+			}
+			err := client.do(ctx, tt.method, apiPath("pipelines"), nil, nil)
+
+			if (err != nil) != tt.wantErr {
+				t.Errorf("err = %v, want error %v", err, tt.wantErr)
+			}
+			if attempts != tt.attempts {
+				t.Errorf("attempts = %d, want %d", attempts, tt.attempts)
+			}
+		})
+	}
+}
+
+func TestRetryNetworkError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	var retries []int
+	policy := testRetryPolicy
+	policy.OnRetry = func(attempt int, wait time.Duration, err error) {
+		retries = append(retries, attempt)
+	}
+	client := NewClient(server.URL, "token").SetRetryPolicy(policy)
+	err := client.do(context.Background(), http.MethodGet, apiPath("pipelines"), nil, nil)
+
+	if !IsNetworkError(err) {
+		t.Fatalf("err = %v, want a NetworkError", err)
+	}
+	if len(retries) != 3 || retries[0] != 1 || retries[2] != 3 {
+		t.Errorf("retries = %v, want [1 2 3]", retries)
+	}
+}
+
+func TestBackoff(t *testing.T) {
+	policy := RetryPolicy{WaitTime: 100 * time.Millisecond, MaxWaitTime: time.Second}
+	tests := []struct {
+		attempt int
+		max     time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 400 * time.Millisecond},
+		{4, 800 * time.Millisecond},
+		{5, time.Second},
+		{10, time.Second},
+	}
+
+	for _, tt := range tests {
+		for i := 0; i < 20; i++ {
+			wait := policy.backoff(tt.attempt)
+			if wait < tt.max/2 || wait > tt.max {
+				t.Fatalf("backoff(%d) = %s, want between %s and %s", tt.attempt, wait, tt.max/2, tt.max)
+			}
+		}
+	}
+
+	if wait := (RetryPolicy{}).backoff(1); wait != 0 {
+		t.Errorf("backoff without wait time = %s, want 0", wait)
+	}
+}
@@ -0,0 +1,142 @@
+package kuberoapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Capabilities of a Kubero UI, named after the endpoints they need. Older
+// servers lack some of them.
+const (
+	CapabilityPipelines    = "pipelines"
+	CapabilityWhoAmI       = "whoami"
+	CapabilityAddons       = "addons"
+	CapabilityBuildpacks   = "buildpacks"
+	CapabilityPodsizes     = "podsizes"
+	CapabilityRepositories = "repositories"
+	CapabilityContexts     = "contexts"
+	CapabilityLogs         = "logs"
+	CapabilityPods         = "pods"
+	CapabilityBuilds       = "builds"
+	CapabilityReleases     = "releases"
+	CapabilityEvents       = "events"
+)
+
+// probeApp fills the path of endpoints below an app. A server knowing the
+// route answers that the app doesn't exist, an older one its HTML page.
+const probeApp = "-"
+
+// capabilityEndpoints are the endpoints probed to detect the capabilities.
+// They are all safe to GET and cheap to answer: lists that may be long, like
+// the pipelines or the events of the cluster, are probed with an item or a
+// namespace that doesn't exist.
+var capabilityEndpoints = map[string]string{
+	CapabilityPipelines:    apiPath("pipelines", probeApp),
+	CapabilityWhoAmI:       apiPath("whoami"),
+	CapabilityAddons:       apiPath("addons"),
+	CapabilityBuildpacks:   apiPath("config", "buildpacks"),
+	CapabilityPodsizes:     apiPath("config", "podsize"),
+	CapabilityRepositories: apiPath("config", "repositories"),
+	CapabilityContexts:     apiPath("config", "k8s", "context"),
+	CapabilityLogs:         apiPath("logs", probeApp, probeApp, probeApp, ContainerWeb, "history"),
+	CapabilityPods:         apiPath("pipelines", probeApp, probeApp, probeApp, "pods"),
+	CapabilityBuilds:       apiPath("pipelines", probeApp, probeApp, probeApp, "builds"),
+	CapabilityReleases:     apiPath("pipelines", probeApp, probeApp, probeApp, "releases"),
+	CapabilityEvents:       apiPath("events") + "?namespace=" + probeApp,
+}
+
+// ServerInfo describes the version and capabilities of a Kubero UI.
+type ServerInfo struct {
+	URL string `json:"url"`
+	// Version is empty if the server doesn't report it.
+	Version      string          `json:"version"`
+	Capabilities map[string]bool `json:"capabilities"`
+	DetectedAt   time.Time       `json:"detectedAt"`
+}
+
+// Supports reports whether the server has the given capability. Unknown
+// capabilities are assumed to be supported, the request tells then.
+func (i *ServerInfo) Supports(capability string) bool {
+	supported, known := i.Capabilities[capability]
+	return supported || !known
+}
+
+// ServerVersion returns the version of the Kubero UI, or an empty string if
+// the server is too old to report it.
+func (c *Client) ServerVersion(ctx context.Context) (string, error) {
+	resp, err := c.execute(ctx, http.MethodGet, apiPath("version"), nil)
+	if IsUnsupported(err) || IsNotFound(err) {
+		return "", nil
+	} else if err != nil {
+		return "", err
+	}
+
+	var payload struct {
+		Version string `json:"version"`
+	}
+	if json.Unmarshal(resp.Body(), &payload) == nil {
+		return payload.Version, nil
+	}
+	return strings.TrimSpace(string(resp.Body())), nil
+}
+
+// DetectServer asks the Kubero UI for its version and probes the endpoints
+// of all capabilities at once. An endpoint counts as supported if the server
+// knows the route, even if the request itself fails, e.g. for lack of
+// permissions.
+func (c *Client) DetectServer(ctx context.Context) (*ServerInfo, error) {
+	version, err := c.ServerVersion(ctx)
+	if err := probeFailed(err); err != nil {
+		return nil, err
+	}
+
+	info := &ServerInfo{
+		URL:          c.BaseURL(),
+		Version:      version,
+		Capabilities: map[string]bool{},
+		DetectedAt:   time.Now(),
+	}
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		probeErr error
+	)
+	for capability, path := range capabilityEndpoints {
+		wg.Add(1)
+		go func(capability string, path string) {
+			defer wg.Done()
+			_, err := c.execute(ctx, http.MethodGet, path, nil)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err := probeFailed(err); err != nil {
+				if probeErr == nil {
+					probeErr = err
+				}
+				return
+			}
+			info.Capabilities[capability] = !IsUnsupported(err)
+		}(capability, path)
+	}
+	wg.Wait()
+
+	if probeErr != nil {
+		return nil, probeErr
+	}
+	return info, nil
+}
+
+// probeFailed returns err unless it is an answer of the server, which tells
+// whether the probed endpoint exists.
+func probeFailed(err error) error {
+	var apiErr *APIError
+	if err == nil || IsUnsupported(err) || errors.As(err, &apiErr) {
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,73 @@
+package kuberoapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestDetectServer(t *testing.T) {
+	var (
+		mu       sync.Mutex
+		requests []string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r.URL.RequestURI())
+		mu.Unlock()
+
+		switch {
+		case r.URL.Path == "/api/cli/version":
+			w.Header().Set("Content-Type", "application/json")
+			w.Write([]byte(`{"version":"v2.4.0"}`))
+		case strings.HasPrefix(r.URL.Path, "/api/cli/logs/"):
+			// an older server answers unknown routes with the page of the UI
+			w.Header().Set("Content-Type", "text/html")
+			w.Write([]byte("<html></html>"))
+		case r.URL.Path == "/api/cli/whoami":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"message":"forbidden"}`))
+		default:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	info, err := NewClient(server.URL, "token").DetectServer(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.Version != "v2.4.0" || info.URL != server.URL {
+		t.Errorf("got %s at %s", info.Version, info.URL)
+	}
+	for capability := range capabilityEndpoints {
+		if supported := capability != CapabilityLogs; info.Capabilities[capability] != supported {
+			t.Errorf("%s supported: %v, want %v", capability, info.Capabilities[capability], supported)
+		}
+	}
+
+	if len(requests) != len(capabilityEndpoints)+1 {
+		t.Errorf("%d requests, want %d", len(requests), len(capabilityEndpoints)+1)
+	}
+	for _, request := range requests {
+		if request == "/api/cli/pipelines" || request == "/api/cli/events" {
+			t.Errorf("probe %s lists all items", request)
+		}
+	}
+}
+
+func TestDetectServerNetworkError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	client := NewClient(server.URL, "token").SetRetryPolicy(RetryPolicy{})
+	if _, err := client.DetectServer(context.Background()); err == nil {
+		t.Error("no error")
+	}
+}